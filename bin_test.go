@@ -0,0 +1,76 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"ml/constant"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func newBinData(t *testing.T) *data.Data {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("x", 0))
+	if err := d.LoadFromCSV(strings.NewReader("1\n2\n3\n4\n10\n11\n12\n13\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func bins(d *data.Data) string {
+	var ret []string
+	for i := 0; i < d.Total(); i++ {
+		ret = append(ret, fmt.Sprint(d.Row(i).Int("x")))
+	}
+	return strings.Join(ret, ",")
+}
+
+func TestBinner(t *testing.T) {
+	cases := []struct {
+		binner data.Binner
+		want   string
+	}{
+		{data.Binner{Strategy: data.BinUniform, Bins: 3}, "0,0,0,0,2,2,2,2"},
+		{data.Binner{Strategy: data.BinQuantile, Bins: 4}, "0,0,1,1,2,2,3,3"},
+		{data.Binner{Strategy: data.BinCustom, Edges: []float64{0, 3, 100}}, "0,0,1,1,1,1,1,1"},
+		{data.Binner{Strategy: data.BinKMeans, Bins: 2}, "0,0,0,0,1,1,1,1"},
+	}
+	for _, c := range cases {
+		d := newBinData(t)
+		if _, err := c.binner.FitTransform(d, d.GetColumnByName("x")); err != nil {
+			t.Fatal(err)
+		}
+		if got := bins(d); got != c.want {
+			t.Fatalf("strategy %d: expect %s, got %s, edges %v", c.binner.Strategy, c.want, got, c.binner.Edges)
+		}
+	}
+	b := data.Binner{Strategy: data.BinKMeans, Bins: 2, OneHot: true}
+	d := newBinData(t)
+	cols, err := b.FitTransform(d, d.GetColumnByName("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 || cols[1].GetName() != "x_bin_1" || d.Row(7).Float("x_bin_1") != 1 {
+		t.Fatal("unexpected one hot bins")
+	}
+	test := newBinData(t)
+	b.OneHot = false
+	if _, err := b.Transform(test, test.GetColumnByName("x")); err != nil {
+		t.Fatal(err)
+	}
+	if bins(test) != "0,0,0,0,1,1,1,1" {
+		t.Fatal("fitted edges not reused")
+	}
+}
+
+func TestBinnerNotFitted(t *testing.T) {
+	d := newBinData(t)
+	var b data.Binner
+	if _, err := b.Transform(d, d.GetColumnByName("x")); !errors.Is(err, constant.ErrInvalidParam) {
+		t.Fatalf("expect invalid param, got %v", err)
+	}
+	if d.GetColumnByName("x").GetType() != data.ColumnFloat || d.Row(7).Float("x") != 13 {
+		t.Fatal("column changed by not fitted binner")
+	}
+}
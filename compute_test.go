@@ -0,0 +1,51 @@
+package ml
+
+import (
+	"errors"
+	"ml/constant"
+	"ml/data"
+	"testing"
+)
+
+func TestComputedColumn(t *testing.T) {
+	d := loadLondon(t)
+	col, err := d.AddComputedColumn("price_per_sale", data.ColumnFloat, func(row data.Row) interface{} {
+		if row.IsNull("houses_sold") || row.Int("houses_sold") == 0 {
+			return nil
+		}
+		return row.Float("average_price") / row.Float("houses_sold")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.GetType() != data.ColumnFloat || d.GetColumnByName("price_per_sale") != col {
+		t.Fatal("computed column not registered")
+	}
+	s := d.Stats(col)
+	if s.Missing != d.Stats(d.GetColumnByName("houses_sold")).Missing {
+		t.Fatalf("unexpected missing: %d", s.Missing)
+	}
+	row := d.Row(0)
+	if row.Float("price_per_sale") != row.Float("average_price")/row.Float("houses_sold") {
+		t.Fatal("unexpected computed value")
+	}
+	_, err = d.AddComputedColumn("bad", data.ColumnInt, func(row data.Row) interface{} {
+		return row.String("area")
+	})
+	if err == nil {
+		t.Fatal("expect error for type mismatch")
+	}
+	if d.GetColumnByName("bad") != nil {
+		t.Fatal("column added on error")
+	}
+	columns := len(d.Columns())
+	_, err = d.AddComputedColumn("area", data.ColumnString, func(row data.Row) interface{} {
+		return "x"
+	})
+	if !errors.Is(err, constant.ErrColumnExists) {
+		t.Fatalf("expect column exists error, got %v", err)
+	}
+	if len(d.Columns()) != columns || d.Row(0).String("area") == "x" {
+		t.Fatal("existing column changed")
+	}
+}
@@ -4,3 +4,36 @@ import "errors"
 
 // ErrNoColumns no columns defined
 var ErrNoColumns = errors.New("No columns defined")
+
+// ErrNoHeader no header row found
+var ErrNoHeader = errors.New("No header row found")
+
+// ErrUnsupportedAggregation aggregation not supported by column type
+var ErrUnsupportedAggregation = errors.New("Unsupported aggregation for column type")
+
+// ErrJoinKey join key not found or type mismatch
+var ErrJoinKey = errors.New("Join key not found or type mismatch")
+
+// ErrValueType value type not match column type
+var ErrValueType = errors.New("Value type not match column type")
+
+// ErrNoValues no valid values to fit
+var ErrNoValues = errors.New("No valid values")
+
+// ErrUnknownCategory category not found in fitted vocabulary
+var ErrUnknownCategory = errors.New("Unknown category")
+
+// ErrInvalidParam invalid parameter
+var ErrInvalidParam = errors.New("Invalid parameter")
+
+// ErrMissingColumn column not found in header
+var ErrMissingColumn = errors.New("Column not found in header")
+
+// ErrDuplicateColumn column found more than once in header
+var ErrDuplicateColumn = errors.New("Column found more than once in header")
+
+// ErrColumnExists column name already exists
+var ErrColumnExists = errors.New("Column already exists")
+
+// ErrMissingField field of column not found in record
+var ErrMissingField = errors.New("Field not found in record")
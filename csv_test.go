@@ -0,0 +1,115 @@
+package ml
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"ml/constant"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestCSVDialect(t *testing.T) {
+	// latin1 encoded semicolon export with decimal comma
+	src := []byte("# exported\nname;price;count\nZ\xfcrich;1,5;NA\nM\xe1laga;-;3\n")
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("name", 0))
+	d.AddColumn(data.NewFloatColumn("price", 1))
+	d.AddColumn(data.NewIntColumn("count", 2))
+	err := d.LoadFromCSVOptions(bytes.NewReader(src), data.CSVOptions{
+		SkipHeader: true,
+		Delimiter:  ';',
+		Comment:    '#',
+		Decimal:    ',',
+		Charset:    "latin1",
+		NullTokens: []string{"NA", "-"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Total() != 2 || d.Row(0).String("name") != "Zürich" || d.Row(1).String("name") != "Málaga" {
+		t.Fatalf("unexpected names:\n%s", d.CSV())
+	}
+	if d.Row(0).Float("price") != 1.5 || !d.Row(0).IsNull("count") || !d.Row(1).IsNull("price") {
+		t.Fatalf("unexpected values:\n%s", d.CSV())
+	}
+
+	tsv := "\xEF\xBB\xBFdate\tprice\n2020-01-01\t1,25\n2020-01-02\tn/a\n"
+	d = data.NewData()
+	err = d.LoadFromCSVOptions(strings.NewReader(tsv), data.CSVOptions{
+		Infer:      true,
+		Delimiter:  '\t',
+		Decimal:    ',',
+		StripBOM:   true,
+		NullTokens: []string{"n/a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.GetColumnByName("date") == nil || d.GetColumnByName("date").GetType() != data.ColumnTime {
+		t.Fatal("BOM not stripped")
+	}
+	if d.GetColumnByName("price").GetType() != data.ColumnFloat || d.Row(0).Float("price") != 1.25 {
+		t.Fatal("decimal comma not inferred")
+	}
+
+	r, err := data.NewCSVReaderOptions(strings.NewReader("a|b\n"), data.CSVOptions{Delimiter: '|'},
+		data.NewStringColumn("b", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := r.Next()
+	if err != nil || row.String("b") != "b" {
+		t.Fatalf("unexpected stream row: %v", err)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("expect EOF, got %v", err)
+	}
+	if err := data.NewData().LoadFromCSVOptions(strings.NewReader(""), data.CSVOptions{Charset: "ebcdic"}); err == nil {
+		t.Fatal("expect error for unsupported charset")
+	}
+	if _, err := data.NewCSVReaderOptions(strings.NewReader("a\n"), data.CSVOptions{Infer: true}); !errors.Is(err, constant.ErrInvalidParam) {
+		t.Fatalf("expect invalid param for stream infer, got %v", err)
+	}
+}
+
+func TestCSVDialectThenJSON(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("k", 0))
+	if err := d.LoadFromCSVOptions(strings.NewReader("NA\n"), data.CSVOptions{NullTokens: []string{"NA"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.LoadFromJSONL(strings.NewReader(`{"k":"NA"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !d.Row(0).IsNull("k") || d.Row(1).IsNull("k") || d.Row(1).String("k") != "NA" {
+		t.Fatalf("null tokens should only apply to csv load:\n%s", d.CSV())
+	}
+}
+
+// failingReader return data together with err once, then io.EOF
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, r.err
+}
+
+func TestCSVCharsetReadError(t *testing.T) {
+	failed := errors.New("read failed")
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("name", 0))
+	err := d.LoadFromCSVOptions(&failingReader{data: []byte("Z\xfcrich\n"), err: failed},
+		data.CSVOptions{Charset: "latin1"})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expect read error, got %v", err)
+	}
+}
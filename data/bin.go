@@ -0,0 +1,183 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"sort"
+)
+
+// BinStrategy how Binner fits bin edges
+type BinStrategy int
+
+const (
+	// BinUniform bins of equal width between min and max
+	BinUniform BinStrategy = iota
+	// BinQuantile bins of equal frequency, duplicated edges are merged
+	BinQuantile
+	// BinCustom bins of user edges
+	BinCustom
+	// BinKMeans bins split at midpoints of 1-D kmeans centers
+	BinKMeans
+)
+
+// binKMeansIter max iterations of BinKMeans
+const binKMeansIter = 100
+
+// Binner discretize int or float column by Edges, Edges are ascending and
+// include the outer edges, values out of range fall into the first or the
+// last bin, a value equal to an inner edge falls into the right bin
+type Binner struct {
+	Strategy BinStrategy `json:"strategy"`
+	Bins     int         `json:"bins"`
+	Edges    []float64   `json:"edges"`
+	// OneHot add float columns named <column>_bin_<i> instead of replacing
+	// column by ordinal int bin
+	OneHot bool `json:"one_hot"`
+}
+
+// Fit fit edges, Edges must be set before for BinCustom
+func (b *Binner) Fit(d *Data, c *Column) error {
+	if b.Strategy == BinCustom {
+		if len(b.Edges) < 2 || !sort.Float64sAreSorted(b.Edges) {
+			return fmt.Errorf("bin edges %v: %w", b.Edges, constant.ErrInvalidParam)
+		}
+		return nil
+	}
+	if b.Bins <= 0 {
+		return fmt.Errorf("bins %d: %w", b.Bins, constant.ErrInvalidParam)
+	}
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	min, max := values[0], values[len(values)-1]
+	edges := make([]float64, 0, b.Bins+1)
+	switch b.Strategy {
+	case BinUniform:
+		for i := 0; i <= b.Bins; i++ {
+			edges = append(edges, min+(max-min)*float64(i)/float64(b.Bins))
+		}
+	case BinQuantile:
+		for i := 0; i <= b.Bins; i++ {
+			edge := quantile(values, float64(i)/float64(b.Bins))
+			if len(edges) == 0 || edge > edges[len(edges)-1] {
+				edges = append(edges, edge)
+			}
+		}
+	case BinKMeans:
+		centers := kmeans1D(values, b.Bins)
+		edges = append(edges, min)
+		for i := 1; i < len(centers); i++ {
+			edges = append(edges, (centers[i-1]+centers[i])/2)
+		}
+		edges = append(edges, max)
+	default:
+		return fmt.Errorf("bin strategy %d: %w", b.Strategy, constant.ErrInvalidParam)
+	}
+	if len(edges) < 2 {
+		edges = append(edges, max)
+	}
+	b.Edges = edges
+	return nil
+}
+
+// Bin get bin index of value
+func (b *Binner) Bin(x float64) int {
+	if len(b.Edges) <= 2 {
+		return 0
+	}
+	inner := b.Edges[1 : len(b.Edges)-1]
+	return sort.Search(len(inner), func(i int) bool {
+		return inner[i] > x
+	})
+}
+
+// Transform replace column by ordinal int bin in place and return it, or
+// add one hot columns and return them when OneHot, missing values are kept,
+// the binner must be fitted or have custom edges
+func (b *Binner) Transform(d *Data, c *Column) ([]*Column, error) {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return nil, fmt.Errorf("bin %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	if len(b.Edges) < 2 {
+		return nil, fmt.Errorf("bin edges %v: %w", b.Edges, constant.ErrInvalidParam)
+	}
+	v := d.vector(c)
+	if !b.OneHot {
+		values := make([]int64, v.n)
+		for i := range values {
+			values[i] = int64(b.Bin(v.number(i)))
+		}
+		v.toInt(func(i int) int64 {
+			return values[i]
+		})
+		c.t = ColumnInt
+		return []*Column{c}, nil
+	}
+	ret := make([]*Column, len(b.Edges)-1)
+	for j := range ret {
+		ret[j] = d.AddColumn(NewFloatColumn(fmt.Sprintf("%s_bin_%d", c.name, j), d.nextIndex()))
+	}
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		bin := b.Bin(v.number(i))
+		for j, col := range ret {
+			bv := d.vector(col)
+			bv.setNull(i, false)
+			if j == bin {
+				bv.f[i] = 1
+			}
+		}
+	}
+	return ret, nil
+}
+
+// FitTransform fit edges and transform column
+func (b *Binner) FitTransform(d *Data, c *Column) ([]*Column, error) {
+	if err := b.Fit(d, c); err != nil {
+		return nil, err
+	}
+	return b.Transform(d, c)
+}
+
+// kmeans1D get ascending centers of sorted values, centers start from
+// quantiles and duplicated centers are merged
+func kmeans1D(values []float64, k int) []float64 {
+	centers := make([]float64, 0, k)
+	for i := 0; i < k; i++ {
+		c := quantile(values, (float64(i)+.5)/float64(k))
+		if len(centers) == 0 || c > centers[len(centers)-1] {
+			centers = append(centers, c)
+		}
+	}
+	for iter := 0; iter < binKMeansIter; iter++ {
+		totals := make([]float64, len(centers))
+		counts := make([]int, len(centers))
+		for _, x := range values {
+			// values and centers are sorted, nearest center by binary search
+			n := sort.SearchFloat64s(centers, x)
+			if n == len(centers) || (n > 0 && x-centers[n-1] <= centers[n]-x) {
+				n--
+			}
+			totals[n] += x
+			counts[n]++
+		}
+		var moved float64
+		for i := range centers {
+			if counts[i] == 0 {
+				continue
+			}
+			c := totals[i] / float64(counts[i])
+			moved = math.Max(moved, math.Abs(c-centers[i]))
+			centers[i] = c
+		}
+		sort.Float64s(centers)
+		if moved == 0 {
+			break
+		}
+	}
+	return centers
+}
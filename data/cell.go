@@ -2,12 +2,13 @@ package data
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
 // Cell data cell
 type Cell struct {
-	t          columnType
+	t          ColumnType
 	s          string
 	i          int
 	f          float64
@@ -21,43 +22,22 @@ func (c *Cell) String() string {
 		return "<null>"
 	}
 	switch c.t {
-	case columnString:
+	case ColumnString:
 		return c.s
-	case columnInt:
+	case ColumnInt:
 		return fmt.Sprintf("%d", c.i)
-	case columnTime:
+	case ColumnFloat:
+		return strconv.FormatFloat(c.f, 'f', -1, 64)
+	case ColumnTime:
 		return c.timeFormat(c.ts)
 	default:
 		return ""
 	}
 }
 
-func (c *Cell) div(target *Cell) {
-	switch c.t {
-	case columnInt:
-		switch target.t {
-		case columnInt:
-			c.t = columnFloat
-			c.f = float64(c.i) / float64(target.i)
-		case columnFloat:
-			c.t = columnFloat
-			c.f = float64(c.i) / target.f
-		}
-	case columnFloat:
-		switch target.t {
-		case columnInt:
-			c.t = columnFloat
-			c.f = c.f / float64(target.i)
-		case columnFloat:
-			c.t = columnFloat
-			c.f = c.f / target.f
-		}
-	}
-}
-
 // Float get float value
 func (c *Cell) Float() float64 {
-	if c.t != columnFloat {
+	if c.t != ColumnFloat {
 		return 0
 	}
 	return c.f
@@ -5,50 +5,94 @@ import (
 	"time"
 )
 
-type columnType int
+// ColumnType type of column
+type ColumnType int
 
 const (
-	columnTime columnType = iota
-	columnString
-	columnInt
-	columnFloat
+	// ColumnTime time column
+	ColumnTime ColumnType = iota
+	// ColumnString string column
+	ColumnString
+	// ColumnInt int column
+	ColumnInt
+	// ColumnFloat float column
+	ColumnFloat
 )
 
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnTime:
+		return "time"
+	case ColumnString:
+		return "string"
+	case ColumnInt:
+		return "int"
+	case ColumnFloat:
+		return "float"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
 // Column data column
 type Column struct {
 	index      int
 	name       string
-	t          columnType
-	timeParse  func(string) time.Time
+	t          ColumnType
+	timeLayout string
+	timeParse  func(string) (time.Time, error)
 	timeFormat func(time.Time) string
 }
 
 // NewStringColumn create string column
 func NewStringColumn(name string, idx int) Column {
-	return Column{index: idx, name: name, t: columnString}
+	return Column{index: idx, name: name, t: ColumnString}
 }
 
 // NewIntColumn create int column
 func NewIntColumn(name string, idx int) Column {
-	return Column{index: idx, name: name, t: columnInt}
+	return Column{index: idx, name: name, t: ColumnInt}
 }
 
 // NewFloatColumn create int column
 func NewFloatColumn(name string, idx int) Column {
-	return Column{index: idx, name: name, t: columnFloat}
+	return Column{index: idx, name: name, t: ColumnFloat}
 }
 
 // NewTimeColumn create time column
 func NewTimeColumn(name string, idx int, parse func(string) time.Time, format func(time.Time) string) Column {
+	return NewTimeParseColumn(name, idx, func(str string) (time.Time, error) {
+		return parse(str), nil
+	}, format)
+}
+
+// NewTimeParseColumn create time column which parse func can report failure
+func NewTimeParseColumn(name string, idx int, parse func(string) (time.Time, error), format func(time.Time) string) Column {
 	return Column{
 		index:      idx,
 		name:       name,
-		t:          columnTime,
+		t:          ColumnTime,
 		timeParse:  parse,
 		timeFormat: format,
 	}
 }
 
+// NewTimeLayoutColumn create time column parsed and formatted by layout
+func NewTimeLayoutColumn(name string, idx int, layout string) Column {
+	return Column{
+		index:      idx,
+		name:       name,
+		t:          ColumnTime,
+		timeLayout: layout,
+		timeParse: func(str string) (time.Time, error) {
+			return time.Parse(layout, str)
+		},
+		timeFormat: func(t time.Time) string {
+			return t.Format(layout)
+		},
+	}
+}
+
 // GetName get name of column
 func (c *Column) GetName() string {
 	return c.name
@@ -59,6 +103,16 @@ func (c *Column) GetIndex() int {
 	return c.index
 }
 
+// GetType get type of column
+func (c *Column) GetType() ColumnType {
+	return c.t
+}
+
+// GetTimeLayout get time layout of column, empty when parsed by callback
+func (c *Column) GetTimeLayout() string {
+	return c.timeLayout
+}
+
 // String get string value
 func (c *Column) String() string {
 	return fmt.Sprintf("%s(%d)", c.name, c.index)
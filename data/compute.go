@@ -0,0 +1,96 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"time"
+)
+
+// AddComputedColumn add column computed by fn from each row, fn returns
+// nil for missing value, float64 NaN is also missing, value type must match t:
+//   - ColumnFloat: float64, float32, int or int64
+//   - ColumnInt: int or int64
+//   - ColumnString: string
+//   - ColumnTime: time.Time, formatted by time.RFC3339
+//
+// name must not be used by another column
+func (d *Data) AddComputedColumn(name string, t ColumnType, fn func(Row) interface{}) (*Column, error) {
+	if _, ok := d.columnsByName[name]; ok {
+		return nil, fmt.Errorf("column %s: %w", name, constant.ErrColumnExists)
+	}
+	v := newVector(t, d.rows)
+	for i := 0; i < d.rows; i++ {
+		value := fn(d.Row(i))
+		if err := v.appendValue(value); err != nil {
+			return nil, fmt.Errorf("row %d, column %s(%s): %T: %w", i, name, t, value, err)
+		}
+	}
+	var col Column
+	if t == ColumnTime {
+		col = NewTimeLayoutColumn(name, d.nextIndex(), time.RFC3339)
+	} else {
+		col = Column{index: d.nextIndex(), name: name, t: t}
+	}
+	ret := d.AddColumn(col)
+	d.vectors[ret.index] = v
+	return ret, nil
+}
+
+func (d *Data) nextIndex() int {
+	if len(d.columnsByIndex) == 0 {
+		return 0
+	}
+	return d.maxIndex() + 1
+}
+
+// appendValue append go value, nil means missing
+func (v *vector) appendValue(value interface{}) error {
+	if value == nil {
+		v.appendNull()
+		return nil
+	}
+	switch v.t {
+	case ColumnFloat:
+		var n float64
+		switch x := value.(type) {
+		case float64:
+			n = x
+		case float32:
+			n = float64(x)
+		case int:
+			n = float64(x)
+		case int64:
+			n = float64(x)
+		default:
+			return constant.ErrValueType
+		}
+		if math.IsNaN(n) {
+			v.appendNull()
+			return nil
+		}
+		v.appendFloat(n)
+	case ColumnInt:
+		switch x := value.(type) {
+		case int:
+			v.appendInt(int64(x))
+		case int64:
+			v.appendInt(x)
+		default:
+			return constant.ErrValueType
+		}
+	case ColumnString:
+		str, ok := value.(string)
+		if !ok {
+			return constant.ErrValueType
+		}
+		v.appendString(str)
+	case ColumnTime:
+		t, ok := value.(time.Time)
+		if !ok {
+			return constant.ErrValueType
+		}
+		v.appendTime(t)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"ml/constant"
+	"strings"
+)
+
+// CSVOptions options of LoadFromCSVOptions, zero value reads comma
+// delimited utf-8 csv without header
+type CSVOptions struct {
+	// SkipHeader skip the first row
+	SkipHeader bool
+	// Infer infer columns not defined by AddColumn from the header row and
+	// the first InferRows rows, the header row is always skipped
+	Infer bool
+	// InferRows sample rows of Infer, DefaultInferRows when 0
+	InferRows int
+	// Delimiter field delimiter, default is comma, '\t' for tsv
+	Delimiter rune
+	// Comment lines beginning with Comment are ignored when not 0
+	Comment rune
+	// LazyQuotes allow quote in unquoted field and non-doubled quote in
+	// quoted field
+	LazyQuotes bool
+	// Decimal decimal separator of float columns, default is '.', digit
+	// grouping is not supported
+	Decimal rune
+	// StripBOM drop utf-8 byte order mark at the beginning
+	StripBOM bool
+	// Charset character set of source, utf-8 when empty, supports
+	// utf-8, latin1 (iso-8859-1) and windows-1252
+	Charset string
+	// NullTokens values read as missing besides empty string, such as NA
+	NullTokens []string
+	// ByName bind columns by header name instead of position, the header
+	// row is always skipped and undeclared header columns are ignored
+	// unless Infer
+	ByName bool
+}
+
+// dialect cell cleaning of csv options
+type dialect struct {
+	decimal rune
+	nulls   map[string]bool
+}
+
+func newDialect(opt CSVOptions) *dialect {
+	ret := &dialect{decimal: opt.Decimal, nulls: make(map[string]bool)}
+	for _, token := range opt.NullTokens {
+		ret.nulls[token] = true
+	}
+	return ret
+}
+
+// clean get cell string to parse by column type
+func (dl *dialect) clean(str string, t ColumnType) string {
+	if dl == nil {
+		return str
+	}
+	if dl.nulls[str] {
+		return ""
+	}
+	if t == ColumnFloat && dl.decimal != 0 && dl.decimal != '.' {
+		return strings.Replace(str, string(dl.decimal), ".", 1)
+	}
+	return str
+}
+
+// newCSVReader create csv reader of options
+func newCSVReader(r io.Reader, opt CSVOptions) (*csv.Reader, error) {
+	r, err := decodeCharset(r, opt.Charset)
+	if err != nil {
+		return nil, err
+	}
+	if opt.StripBOM {
+		br := bufio.NewReader(r)
+		bom, err := br.Peek(3)
+		if err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+			br.Discard(3)
+		}
+		r = br
+	}
+	cr := csv.NewReader(r)
+	if opt.Delimiter != 0 {
+		cr.Comma = opt.Delimiter
+	}
+	cr.Comment = opt.Comment
+	cr.LazyQuotes = opt.LazyQuotes
+	// short records are reported by addRow as cell errors
+	cr.FieldsPerRecord = -1
+	return cr, nil
+}
+
+// LoadFromCSVOptions read data from csv by options
+func (d *Data) LoadFromCSVOptions(r io.Reader, opt CSVOptions) error {
+	cr, err := newCSVReader(r, opt)
+	if err != nil {
+		return err
+	}
+	dl := newDialect(opt)
+	d.parseErrors = nil
+	if opt.Infer {
+		return d.loadCSVInfer(cr, dl, opt.InferRows, opt.ByName)
+	}
+	if len(d.columnsByIndex) == 0 {
+		return constant.ErrNoColumns
+	}
+	var record int
+	var positions map[int]int
+	if opt.ByName {
+		header, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				return constant.ErrNoHeader
+			}
+			return err
+		}
+		positions, err = bindHeader(header, d.Columns())
+		if err != nil {
+			return err
+		}
+		record++
+	} else if opt.SkipHeader {
+		_, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				d.loaded = true
+				return nil
+			}
+			return err
+		}
+		record++
+	}
+	return d.readCSV(cr, positions, dl, record)
+}
+
+func (d *Data) readCSV(cr *csv.Reader, positions map[int]int, dl *dialect, record int) error {
+	cols := d.Columns()
+	for {
+		row, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				d.loaded = true
+				return nil
+			}
+			return err
+		}
+		record++
+		if err := d.addRow(cols, positions, dl, record, row); err != nil {
+			return err
+		}
+	}
+}
+
+// bindHeader map column index to field position of the header by column
+// name, all columns must appear exactly once in header
+func bindHeader(header []string, cols []*Column) (map[int]int, error) {
+	fields := make(map[string][]int, len(header))
+	for pos, name := range header {
+		fields[name] = append(fields[name], pos)
+	}
+	ret := make(map[int]int, len(cols))
+	var missing, duplicate []string
+	for _, col := range cols {
+		switch len(fields[col.name]) {
+		case 0:
+			missing = append(missing, col.name)
+		case 1:
+			ret[col.index] = fields[col.name][0]
+		default:
+			duplicate = append(duplicate, col.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("columns %s: %w", strings.Join(missing, ", "), constant.ErrMissingColumn)
+	}
+	if len(duplicate) > 0 {
+		return nil, fmt.Errorf("columns %s: %w", strings.Join(duplicate, ", "), constant.ErrDuplicateColumn)
+	}
+	return ret, nil
+}
+
+// decodeCharset convert source to utf-8
+func decodeCharset(r io.Reader, charset string) (io.Reader, error) {
+	switch strings.ToLower(strings.Replace(charset, "_", "-", -1)) {
+	case "", "utf-8", "utf8":
+		return r, nil
+	case "latin1", "latin-1", "iso-8859-1":
+		return &byteDecoder{r: r}, nil
+	case "windows-1252", "cp1252":
+		return &byteDecoder{r: r, table: &windows1252}, nil
+	default:
+		return nil, fmt.Errorf("charset %s: %w", charset, constant.ErrInvalidParam)
+	}
+}
+
+// byteDecoder decode single byte charset to utf-8, bytes are latin1 code
+// points except 0x80-0x9F mapped by table
+type byteDecoder struct {
+	r     io.Reader
+	table *[32]rune
+	in    []byte
+	out   []byte
+	// err error of source returned after out is drained
+	err error
+}
+
+func (dec *byteDecoder) Read(p []byte) (int, error) {
+	for len(dec.out) == 0 {
+		if dec.err != nil {
+			return 0, dec.err
+		}
+		if cap(dec.in) == 0 {
+			dec.in = make([]byte, 4096)
+		}
+		n, err := dec.r.Read(dec.in[:cap(dec.in)])
+		for _, b := range dec.in[:n] {
+			c := rune(b)
+			if dec.table != nil && b >= 0x80 && b < 0xA0 {
+				c = dec.table[b-0x80]
+			}
+			dec.out = append(dec.out, string(c)...)
+		}
+		dec.err = err
+		if n == 0 && err == nil {
+			return 0, nil
+		}
+	}
+	n := copy(p, dec.out)
+	dec.out = dec.out[n:]
+	return n, nil
+}
+
+// windows1252 code points of 0x80-0x9F in windows-1252, undefined bytes
+// are kept as latin1
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
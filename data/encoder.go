@@ -0,0 +1,220 @@
+package data
+
+import (
+	"fmt"
+	"ml/constant"
+	"sort"
+)
+
+// UnknownPolicy how encoders handle categories not in vocabulary
+type UnknownPolicy int
+
+const (
+	// UnknownError return constant.ErrUnknownCategory
+	UnknownError UnknownPolicy = iota
+	// UnknownIgnore encode as missing for label encoder and all zeros for
+	// one hot encoder
+	UnknownIgnore
+	// UnknownOther encode as the OtherCategory bucket after all categories
+	UnknownOther
+)
+
+// OtherCategory name of the bucket for unknown categories
+const OtherCategory = "other"
+
+// LabelEncoder encode string column to int codes by index of Categories,
+// Fit learns sorted categories when Categories is empty, set Categories
+// before Fit for ordinal encoding by user order
+type LabelEncoder struct {
+	Categories []string      `json:"categories"`
+	Unknown    UnknownPolicy `json:"unknown"`
+}
+
+// NewOrdinalEncoder create label encoder with categories in user order
+func NewOrdinalEncoder(categories []string, unknown UnknownPolicy) *LabelEncoder {
+	return &LabelEncoder{Categories: categories, Unknown: unknown}
+}
+
+// Fit learn categories of string column
+func (e *LabelEncoder) Fit(d *Data, c *Column) error {
+	if len(e.Categories) > 0 {
+		return nil
+	}
+	categories, err := fitCategories(d, c)
+	if err != nil {
+		return err
+	}
+	e.Categories = categories
+	return nil
+}
+
+// Transform encode string column to int column in place
+func (e *LabelEncoder) Transform(d *Data, c *Column) error {
+	codes, err := encodeCategories(d, c, e.Categories, e.Unknown)
+	if err != nil {
+		return err
+	}
+	v := d.vector(c)
+	v.toInt(func(i int) int64 {
+		return int64(codes[i])
+	})
+	for i, code := range codes {
+		if code < 0 {
+			v.setNull(i, true)
+		}
+	}
+	c.t = ColumnInt
+	return nil
+}
+
+// FitTransform fit categories and encode column
+func (e *LabelEncoder) FitTransform(d *Data, c *Column) error {
+	if err := e.Fit(d, c); err != nil {
+		return err
+	}
+	return e.Transform(d, c)
+}
+
+// InverseTransform decode int column to string column in place, codes out
+// of categories are decoded as OtherCategory
+func (e *LabelEncoder) InverseTransform(d *Data, c *Column) error {
+	if c.t != ColumnInt {
+		return fmt.Errorf("decode %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	v := d.vector(c)
+	v.s = make([]string, v.n)
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		code := int(v.i[i])
+		if code >= 0 && code < len(e.Categories) {
+			v.s[i] = e.Categories[code]
+		} else {
+			v.s[i] = OtherCategory
+		}
+	}
+	v.i = nil
+	v.t = ColumnString
+	c.t = ColumnString
+	return nil
+}
+
+// OneHotEncoder encode string column to float columns named
+// <column>_onehot_<category> by order of Categories, Fit learns sorted
+// categories when Categories is empty
+type OneHotEncoder struct {
+	Categories []string      `json:"categories"`
+	Unknown    UnknownPolicy `json:"unknown"`
+	// DropFirst skip column of the first category
+	DropFirst bool `json:"drop_first"`
+}
+
+// Fit learn categories of string column
+func (e *OneHotEncoder) Fit(d *Data, c *Column) error {
+	if len(e.Categories) > 0 {
+		return nil
+	}
+	categories, err := fitCategories(d, c)
+	if err != nil {
+		return err
+	}
+	e.Categories = categories
+	return nil
+}
+
+// Transform add one hot columns, rows with missing category are missing
+func (e *OneHotEncoder) Transform(d *Data, c *Column) ([]*Column, error) {
+	codes, err := encodeCategories(d, c, e.Categories, e.Unknown)
+	if err != nil {
+		return nil, err
+	}
+	names := e.Categories
+	if e.Unknown == UnknownOther {
+		names = append(names[:len(names):len(names)], OtherCategory)
+	}
+	first := 0
+	if e.DropFirst {
+		first = 1
+	}
+	src := d.vector(c)
+	ret := make([]*Column, 0, len(names))
+	for j := first; j < len(names); j++ {
+		col := d.AddColumn(NewFloatColumn(c.name+"_onehot_"+names[j], d.nextIndex()))
+		v := d.vector(col)
+		for i, code := range codes {
+			if src.isNull(i) {
+				continue
+			}
+			v.setNull(i, false)
+			if code == j {
+				v.f[i] = 1
+			}
+		}
+		ret = append(ret, col)
+	}
+	return ret, nil
+}
+
+// FitTransform fit categories and add one hot columns
+func (e *OneHotEncoder) FitTransform(d *Data, c *Column) ([]*Column, error) {
+	if err := e.Fit(d, c); err != nil {
+		return nil, err
+	}
+	return e.Transform(d, c)
+}
+
+// fitCategories get sorted unique values of string column
+func fitCategories(d *Data, c *Column) ([]string, error) {
+	if c.t != ColumnString {
+		return nil, fmt.Errorf("fit %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	v := d.vector(c)
+	unique := make(map[string]bool)
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			unique[v.s[i]] = true
+		}
+	}
+	ret := make([]string, 0, len(unique))
+	for k := range unique {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// encodeCategories get code of each row, -1 for missing or ignored unknown,
+// len(categories) for UnknownOther
+func encodeCategories(d *Data, c *Column, categories []string, unknown UnknownPolicy) ([]int, error) {
+	if c.t != ColumnString {
+		return nil, fmt.Errorf("encode %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	index := make(map[string]int, len(categories))
+	for i, category := range categories {
+		if _, ok := index[category]; !ok {
+			index[category] = i
+		}
+	}
+	v := d.vector(c)
+	ret := make([]int, v.n)
+	for i := range ret {
+		if v.isNull(i) {
+			ret[i] = -1
+			continue
+		}
+		code, ok := index[v.s[i]]
+		if !ok {
+			switch unknown {
+			case UnknownIgnore:
+				code = -1
+			case UnknownOther:
+				code = len(categories)
+			default:
+				return nil, fmt.Errorf("row %d, column %s: %q: %w", i, c.name, v.s[i], constant.ErrUnknownCategory)
+			}
+		}
+		ret[i] = code
+	}
+	return ret, nil
+}
@@ -0,0 +1,125 @@
+package data
+
+import "math"
+
+// FillForward fill missing data by the last valid value before in window
+// partition, leading missing values are kept
+func (d *Data) FillForward(c *Column, w Window) {
+	v := d.vector(c)
+	for _, rows := range d.partitions(w) {
+		last := -1
+		for _, i := range rows {
+			if !v.isNull(i) {
+				last = i
+				continue
+			}
+			if last >= 0 {
+				v.setFrom(i, v, last)
+			}
+		}
+	}
+}
+
+// FillBackward fill missing data by the next valid value after in window
+// partition, trailing missing values are kept
+func (d *Data) FillBackward(c *Column, w Window) {
+	v := d.vector(c)
+	for _, rows := range d.partitions(w) {
+		next := -1
+		for p := len(rows) - 1; p >= 0; p-- {
+			i := rows[p]
+			if !v.isNull(i) {
+				next = i
+				continue
+			}
+			if next >= 0 {
+				v.setFrom(i, v, next)
+			}
+		}
+	}
+}
+
+// FillInterpolate fill missing int or float data by linear interpolation
+// between the valid values around in window partition, distance is
+// measured by window OrderBy column when it is int, float or time, by
+// position otherwise, leading and trailing missing values are kept,
+// interpolated int values are rounded
+func (d *Data) FillInterpolate(c *Column, w Window) {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return
+	}
+	v := d.vector(c)
+	var order *vector
+	if w.OrderBy != nil {
+		order = d.vector(w.OrderBy)
+	}
+	position := func(rows []int, p int) (float64, bool) {
+		if order == nil {
+			return float64(p), true
+		}
+		i := rows[p]
+		if order.isNull(i) {
+			return 0, false
+		}
+		switch order.t {
+		case ColumnInt, ColumnFloat:
+			return order.number(i), true
+		case ColumnTime:
+			return float64(order.ts[i].UnixNano()), true
+		default:
+			return float64(p), true
+		}
+	}
+	for _, rows := range d.partitions(w) {
+		prev := -1
+		for p, i := range rows {
+			if v.isNull(i) {
+				continue
+			}
+			if prev >= 0 && p-prev > 1 {
+				x0, ok0 := position(rows, prev)
+				x1, ok1 := position(rows, p)
+				y0, y1 := v.number(rows[prev]), v.number(i)
+				for q := prev + 1; q < p; q++ {
+					x, ok := position(rows, q)
+					if !ok || !ok0 || !ok1 || x1 == x0 {
+						continue
+					}
+					y := y0 + (y1-y0)*(x-x0)/(x1-x0)
+					if v.t == ColumnInt {
+						v.i[rows[q]] = int64(math.Round(y))
+					} else {
+						v.f[rows[q]] = y
+					}
+					v.setNull(rows[q], false)
+				}
+			}
+			prev = p
+		}
+	}
+}
+
+// FillGroup fill missing data by fn computed in each group of by columns,
+// such as mean price of the same area
+func (d *Data) FillGroup(c *Column, fn numberFunc, by ...*Column) {
+	v := d.vector(c)
+	for _, rows := range d.GroupBy(by...).Groups() {
+		sub := NewData()
+		col := sub.AddColumn(*c)
+		sv := newVector(c.t, len(rows))
+		for _, i := range rows {
+			sv.appendFrom(v, i)
+		}
+		sub.vectors[col.index] = sv
+		sub.rows = len(rows)
+		cell, ok := fn(sub, col)
+		if !ok {
+			continue
+		}
+		for _, i := range rows {
+			if v.isNull(i) {
+				v.setCell(i, cell)
+			}
+		}
+	}
+}
@@ -1,58 +1,141 @@
 package data
 
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+)
+
 type numberFunc func(*Data, *Column) (*Cell, bool)
 type hashFunc func(*Cell) int
 
-// Mean number func get mean value
+// Mean number func get mean value of valid values, int mean is rounded
 func Mean(d *Data, c *Column) (*Cell, bool) {
-	switch c.t {
-	case columnInt:
-		var total int
-		for _, row := range d.cellsByIndex {
-			total += row[c.index].i
+	v := d.vector(c)
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return nil, false
+	}
+	var total float64
+	var valid int
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			total += v.number(i)
+			valid++
 		}
-		return &Cell{
-			t: c.t,
-			i: total / len(d.cellsByIndex),
-		}, true
-	case columnFloat:
-		var total float64
-		for _, row := range d.cellsByIndex {
-			total += row[c.index].f
+	}
+	if valid == 0 {
+		return nil, false
+	}
+	return numberCell(c.t, total/float64(valid)), true
+}
+
+// Median number func get median value of valid values, int median is rounded
+func Median(d *Data, c *Column) (*Cell, bool) {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return nil, false
+	}
+	values := d.vector(c).numbers()
+	if len(values) == 0 {
+		return nil, false
+	}
+	sort.Float64s(values)
+	return numberCell(c.t, quantile(values, .5)), true
+}
+
+// Mode number func get most frequent valid value of any column type,
+// the smallest one is chosen when tie
+func Mode(d *Data, c *Column) (*Cell, bool) {
+	v := d.vector(c)
+	counts := make(map[string]int)
+	first := make(map[string]int)
+	var buf strings.Builder
+	best := -1
+	var bestKey string
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
 		}
-		return &Cell{
-			t: c.t,
-			f: total / float64(len(d.cellsByIndex)),
-		}, true
-	default:
+		buf.Reset()
+		v.writeKey(&buf, i)
+		key := buf.String()
+		if _, ok := first[key]; !ok {
+			first[key] = i
+		}
+		counts[key]++
+		n := counts[key]
+		if best < 0 || n > counts[bestKey] ||
+			(n == counts[bestKey] && v.compare(i, first[bestKey]) < 0) {
+			best = first[key]
+			bestKey = key
+		}
+	}
+	if best < 0 {
 		return nil, false
 	}
+	return v.cell(best, c), true
+}
+
+// Constant number func get constant value, value type must match column
+// type as AddComputedColumn
+func Constant(value interface{}) numberFunc {
+	return func(d *Data, c *Column) (*Cell, bool) {
+		v := newVector(c.t, 1)
+		if err := v.appendValue(value); err != nil {
+			return nil, false
+		}
+		return v.cell(0, c), true
+	}
 }
 
-// Max number func get max value
+func numberCell(t ColumnType, n float64) *Cell {
+	if t == ColumnInt {
+		return &Cell{t: t, i: int(math.Round(n))}
+	}
+	return &Cell{t: t, f: n}
+}
+
+// Max number func get max value of valid values
 func Max(d *Data, c *Column) (*Cell, bool) {
+	v := d.vector(c)
 	switch c.t {
-	case columnInt:
-		cell := d.cellsByIndex[0][c.index]
-		for _, row := range d.cellsByIndex {
-			if row[c.index].i > cell.i {
-				cell = row[c.index]
+	case ColumnInt:
+		var max int64
+		found := false
+		for i := 0; i < v.n; i++ {
+			if v.isNull(i) {
+				continue
+			}
+			if !found || v.i[i] > max {
+				max = v.i[i]
+				found = true
 			}
 		}
+		if !found {
+			return nil, false
+		}
 		return &Cell{
-			t: columnInt,
-			i: cell.i,
+			t: ColumnInt,
+			i: int(max),
 		}, true
-	case columnFloat:
-		cell := d.cellsByIndex[0][c.index]
-		for _, row := range d.cellsByIndex {
-			if row[c.index].f > cell.f {
-				cell = row[c.index]
+	case ColumnFloat:
+		var max float64
+		found := false
+		for i := 0; i < v.n; i++ {
+			if v.isNull(i) {
+				continue
 			}
+			if !found || v.f[i] > max {
+				max = v.f[i]
+				found = true
+			}
+		}
+		if !found {
+			return nil, false
 		}
 		return &Cell{
-			t: columnFloat,
-			f: cell.f,
+			t: ColumnFloat,
+			f: max,
 		}, true
 	default:
 		return nil, false
@@ -61,8 +144,18 @@ func Max(d *Data, c *Column) (*Cell, bool) {
 
 // Length hash func for length
 func Length(c *Cell) int {
-	if c.t != columnString {
+	if c.t != ColumnString {
 		return 0
 	}
 	return len(c.s)
 }
+
+// FNV hash func for string by 32-bit FNV-1a
+func FNV(c *Cell) int {
+	if c.t != ColumnString {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(c.s))
+	return int(h.Sum32())
+}
@@ -0,0 +1,268 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AggFunc aggregation function
+type AggFunc int
+
+const (
+	// AggCount count of valid values
+	AggCount AggFunc = iota
+	// AggSum sum of values
+	AggSum
+	// AggMean mean of values
+	AggMean
+	// AggMedian median of values
+	AggMedian
+	// AggMin min value
+	AggMin
+	// AggMax max value
+	AggMax
+	// AggStd sample standard deviation of values
+	AggStd
+	// AggFirst first valid value
+	AggFirst
+	// AggLast last valid value
+	AggLast
+)
+
+func (fn AggFunc) String() string {
+	switch fn {
+	case AggCount:
+		return "count"
+	case AggSum:
+		return "sum"
+	case AggMean:
+		return "mean"
+	case AggMedian:
+		return "median"
+	case AggMin:
+		return "min"
+	case AggMax:
+		return "max"
+	case AggStd:
+		return "std"
+	case AggFirst:
+		return "first"
+	case AggLast:
+		return "last"
+	default:
+		return fmt.Sprintf("agg(%d)", int(fn))
+	}
+}
+
+// Aggregation aggregate column by function, result column is named
+// <column>_<function>
+type Aggregation struct {
+	Column *Column
+	Func   AggFunc
+}
+
+// Agg create aggregation
+func Agg(c *Column, fn AggFunc) Aggregation {
+	return Aggregation{Column: c, Func: fn}
+}
+
+// Grouped data grouped by key columns
+type Grouped struct {
+	d      *Data
+	keys   []*Column
+	groups [][]int
+}
+
+// GroupBy group rows by columns, groups are ordered by key values and
+// missing keys are grouped together
+func (d *Data) GroupBy(cols ...*Column) *Grouped {
+	index := make(map[string]int)
+	var groups [][]int
+	var buf strings.Builder
+	for i := 0; i < d.rows; i++ {
+		buf.Reset()
+		for _, col := range cols {
+			d.vector(col).writeKey(&buf, i)
+		}
+		key := buf.String()
+		n, ok := index[key]
+		if !ok {
+			n = len(groups)
+			index[key] = n
+			groups = append(groups, nil)
+		}
+		groups[n] = append(groups[n], i)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		for _, col := range cols {
+			v := d.vector(col)
+			if n := v.compare(groups[i][0], groups[j][0]); n != 0 {
+				return n < 0
+			}
+		}
+		return false
+	})
+	return &Grouped{d: d, keys: cols, groups: groups}
+}
+
+// Groups get row indexes of each group
+func (g *Grouped) Groups() [][]int {
+	return g.groups
+}
+
+// Agg aggregate each group into one row, result contains key columns
+// followed by aggregated columns
+func (g *Grouped) Agg(aggs ...Aggregation) (*Data, error) {
+	ret := NewData()
+	first := make([]int, len(g.groups))
+	for i, rows := range g.groups {
+		first[i] = rows[0]
+	}
+	for idx, key := range g.keys {
+		col := *key
+		col.index = idx
+		ret.AddColumn(col)
+		src := g.d.vector(key)
+		v := newVector(key.t, len(first))
+		for _, i := range first {
+			v.appendFrom(src, i)
+		}
+		ret.vectors[idx] = v
+	}
+	for i, agg := range aggs {
+		src := g.d.vector(agg.Column)
+		t, ok := agg.Func.resultType(agg.Column.t)
+		if !ok {
+			return nil, fmt.Errorf("%s of %s(%s): %w", agg.Func, agg.Column.name,
+				agg.Column.t, constant.ErrUnsupportedAggregation)
+		}
+		col := *agg.Column
+		col.index = len(g.keys) + i
+		col.name = agg.Column.name + "_" + agg.Func.String()
+		col.t = t
+		ret.AddColumn(col)
+		v := newVector(t, len(g.groups))
+		for _, rows := range g.groups {
+			agg.Func.apply(v, src, rows)
+		}
+		ret.vectors[col.index] = v
+	}
+	ret.rows = len(g.groups)
+	ret.loaded = true
+	return ret, nil
+}
+
+func (fn AggFunc) resultType(t ColumnType) (ColumnType, bool) {
+	numeric := t == ColumnInt || t == ColumnFloat
+	switch fn {
+	case AggCount:
+		return ColumnInt, true
+	case AggSum:
+		return t, numeric
+	case AggMean, AggMedian, AggStd:
+		return ColumnFloat, numeric
+	case AggMin, AggMax, AggFirst, AggLast:
+		return t, true
+	default:
+		return t, false
+	}
+}
+
+// apply aggregate rows of src and append result to dst
+func (fn AggFunc) apply(dst, src *vector, rows []int) {
+	valid := make([]int, 0, len(rows))
+	for _, i := range rows {
+		if !src.isNull(i) {
+			valid = append(valid, i)
+		}
+	}
+	if fn == AggCount {
+		dst.appendInt(int64(len(valid)))
+		return
+	}
+	if len(valid) == 0 {
+		dst.appendNull()
+		return
+	}
+	switch fn {
+	case AggSum:
+		if src.t == ColumnInt {
+			var total int64
+			for _, i := range valid {
+				total += src.i[i]
+			}
+			dst.appendInt(total)
+			return
+		}
+		var total float64
+		for _, i := range valid {
+			total += src.f[i]
+		}
+		dst.appendFloat(total)
+	case AggMean:
+		dst.appendFloat(mean(src, valid))
+	case AggMedian:
+		values := make([]float64, len(valid))
+		for j, i := range valid {
+			values[j] = src.number(i)
+		}
+		sort.Float64s(values)
+		dst.appendFloat(quantile(values, .5))
+	case AggStd:
+		if len(valid) < 2 {
+			dst.appendNull()
+			return
+		}
+		avg := mean(src, valid)
+		var total float64
+		for _, i := range valid {
+			diff := src.number(i) - avg
+			total += diff * diff
+		}
+		dst.appendFloat(math.Sqrt(total / float64(len(valid)-1)))
+	case AggMin, AggMax:
+		n := valid[0]
+		for _, i := range valid[1:] {
+			cmp := src.compare(i, n)
+			if (fn == AggMin && cmp < 0) || (fn == AggMax && cmp > 0) {
+				n = i
+			}
+		}
+		dst.appendFrom(src, n)
+	case AggFirst:
+		dst.appendFrom(src, valid[0])
+	case AggLast:
+		dst.appendFrom(src, valid[len(valid)-1])
+	}
+}
+
+func mean(v *vector, rows []int) float64 {
+	var total float64
+	for _, i := range rows {
+		total += v.number(i)
+	}
+	return total / float64(len(rows))
+}
+
+// writeKey write group key of row i
+func (v *vector) writeKey(buf *strings.Builder, i int) {
+	if v.isNull(i) {
+		buf.WriteString("\x00n")
+		return
+	}
+	buf.WriteString("\x00v")
+	switch v.t {
+	case ColumnFloat:
+		buf.WriteString(strconv.FormatFloat(v.f[i], 'g', -1, 64))
+	case ColumnInt:
+		buf.WriteString(strconv.FormatInt(v.i[i], 10))
+	case ColumnString:
+		buf.WriteString(strconv.Quote(v.s[i]))
+	case ColumnTime:
+		buf.WriteString(strconv.FormatInt(v.ts[i].UnixNano(), 10))
+	}
+}
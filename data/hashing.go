@@ -0,0 +1,65 @@
+package data
+
+import (
+	"fmt"
+	"hash/fnv"
+	"ml/constant"
+	"strings"
+)
+
+// HashingEncoder encode one or more columns into Buckets float columns
+// named <columns>_hash_<i> by signed feature hashing, the key is the cell
+// strings of columns joined by \x1f and hashed by 64-bit FNV-1a, bucket is
+// hash mod Buckets and the sign is the highest bit, so results are stable
+// across runs and processes
+type HashingEncoder struct {
+	Buckets int `json:"buckets"`
+}
+
+// Transform add hashed columns, rows with any missing value are missing
+func (e *HashingEncoder) Transform(d *Data, cols ...*Column) ([]*Column, error) {
+	if e.Buckets <= 0 || len(cols) == 0 {
+		return nil, fmt.Errorf("hashing %d buckets of %d columns: %w", e.Buckets, len(cols), constant.ErrInvalidParam)
+	}
+	names := make([]string, len(cols))
+	vectors := make([]*vector, len(cols))
+	for j, col := range cols {
+		names[j] = col.name
+		vectors[j] = d.vector(col)
+	}
+	prefix := strings.Join(names, "_") + "_hash_"
+	ret := make([]*Column, e.Buckets)
+	buckets := make([]*vector, e.Buckets)
+	for j := range ret {
+		ret[j] = d.AddColumn(NewFloatColumn(fmt.Sprintf("%s%d", prefix, j), d.nextIndex()))
+		buckets[j] = d.vector(ret[j])
+	}
+	values := make([]string, len(cols))
+next:
+	for i := 0; i < d.rows; i++ {
+		for j, v := range vectors {
+			if v.isNull(i) {
+				continue next
+			}
+			values[j] = v.cell(i, cols[j]).String()
+		}
+		bucket, sign := e.Hash(values...)
+		for _, v := range buckets {
+			v.setNull(i, false)
+		}
+		buckets[bucket].f[i] += sign
+	}
+	return ret, nil
+}
+
+// Hash get bucket and sign of values
+func (e *HashingEncoder) Hash(values ...string) (int, float64) {
+	h := fnv.New64a()
+	h.Write([]byte(strings.Join(values, "\x1f")))
+	n := h.Sum64()
+	sign := 1.
+	if n>>63 == 1 {
+		sign = -1
+	}
+	return int(n % uint64(e.Buckets)), sign
+}
@@ -0,0 +1,205 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"ml/model"
+	"sort"
+)
+
+const (
+	// IterativeEpochs train epochs of each regression in FillIterative
+	IterativeEpochs = 200
+	// IterativeLearnRate learn rate of each regression in FillIterative
+	IterativeLearnRate = 0.1
+)
+
+// FillKNN fill missing data by mean or mode of the k nearest rows, features
+// must be int or float
+func (d *Data) FillKNN(c *Column, k int, features ...*Column) error {
+	if k <= 0 {
+		k = 5
+	}
+	vectors := make([]*vector, 0, len(features))
+	scales := make([]float64, 0, len(features))
+	for _, col := range features {
+		if col.t != ColumnInt && col.t != ColumnFloat {
+			return fmt.Errorf("knn feature %s(%s): %w", col.name, col.t, constant.ErrValueType)
+		}
+		if col.index == c.index {
+			continue
+		}
+		vectors = append(vectors, d.vector(col))
+		s := d.Stats(col)
+		scale := s.Std
+		if scale == 0 || math.IsNaN(scale) {
+			scale = 1
+		}
+		scales = append(scales, scale)
+	}
+	if len(vectors) == 0 {
+		return fmt.Errorf("knn of %s without features: %w", c.name, constant.ErrInvalidParam)
+	}
+	v := d.vector(c)
+	donors := make([]int, 0, v.n)
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			donors = append(donors, i)
+		}
+	}
+	type neighbour struct {
+		row  int
+		dist float64
+	}
+	distance := func(i, j int) float64 {
+		var total float64
+		var used int
+		for n, fv := range vectors {
+			if fv.isNull(i) || fv.isNull(j) {
+				continue
+			}
+			diff := (fv.number(i) - fv.number(j)) / scales[n]
+			total += diff * diff
+			used++
+		}
+		if used == 0 {
+			return math.Inf(1)
+		}
+		return math.Sqrt(total * float64(len(vectors)) / float64(used))
+	}
+	var fills []int
+	var cells []*Cell
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			continue
+		}
+		neighbours := make([]neighbour, 0, len(donors))
+		for _, j := range donors {
+			dist := distance(i, j)
+			if !math.IsInf(dist, 1) {
+				neighbours = append(neighbours, neighbour{row: j, dist: dist})
+			}
+		}
+		if len(neighbours) == 0 {
+			continue
+		}
+		sort.SliceStable(neighbours, func(a, b int) bool {
+			return neighbours[a].dist < neighbours[b].dist
+		})
+		if len(neighbours) > k {
+			neighbours = neighbours[:k]
+		}
+		sub := NewData()
+		col := sub.AddColumn(*c)
+		sv := newVector(c.t, len(neighbours))
+		for _, n := range neighbours {
+			sv.appendFrom(v, n.row)
+		}
+		sub.vectors[col.index] = sv
+		sub.rows = len(neighbours)
+		fn := Mode
+		if c.t == ColumnInt || c.t == ColumnFloat {
+			fn = Mean
+		}
+		if cell, ok := fn(sub, col); ok {
+			fills = append(fills, i)
+			cells = append(cells, cell)
+		}
+	}
+	// fill after all neighbours found, filled values are not donors
+	for n, i := range fills {
+		v.setCell(i, cells[n])
+	}
+	return nil
+}
+
+// FillIterative fill missing int and float data by regressing each column
+// on the others until converged by tol or maxIter, return rounds used
+func (d *Data) FillIterative(cols []*Column, maxIter int, tol float64) (int, error) {
+	vectors := make([]*vector, len(cols))
+	missing := make([][]int, len(cols))
+	means := make([]float64, len(cols))
+	stds := make([]float64, len(cols))
+	for n, col := range cols {
+		if col.t != ColumnInt && col.t != ColumnFloat {
+			return 0, fmt.Errorf("iterative column %s(%s): %w", col.name, col.t, constant.ErrValueType)
+		}
+		v := d.vector(col)
+		vectors[n] = v
+		s := d.Stats(col)
+		means[n], stds[n] = s.Mean, s.Std
+		if stds[n] == 0 || math.IsNaN(stds[n]) {
+			stds[n] = 1
+		}
+		for i := 0; i < v.n; i++ {
+			if v.isNull(i) {
+				missing[n] = append(missing[n], i)
+			}
+		}
+	}
+	// scaled working copy, missing values start from mean
+	values := make([][]float64, len(cols))
+	for n, v := range vectors {
+		values[n] = make([]float64, d.rows)
+		for i := 0; i < d.rows; i++ {
+			if !v.isNull(i) {
+				values[n][i] = (v.number(i) - means[n]) / stds[n]
+			}
+		}
+	}
+	var round int
+	for round < maxIter {
+		round++
+		var change float64
+		for n := range cols {
+			if len(missing[n]) == 0 {
+				continue
+			}
+			isMissing := make(map[int]bool, len(missing[n]))
+			for _, i := range missing[n] {
+				isMissing[i] = true
+			}
+			row := func(i int) []float64 {
+				ret := make([]float64, 1, len(cols))
+				ret[0] = 1
+				for m := range cols {
+					if m != n {
+						ret = append(ret, values[m][i])
+					}
+				}
+				return ret
+			}
+			features := make([][]float64, 0, d.rows-len(missing[n]))
+			labels := make([]float64, 0, d.rows-len(missing[n]))
+			for i := 0; i < d.rows; i++ {
+				if !isMissing[i] {
+					features = append(features, row(i))
+					labels = append(labels, values[n][i])
+				}
+			}
+			if len(features) == 0 {
+				continue
+			}
+			var lr model.LinearRegression
+			lr.Begin(len(cols))
+			for epoch := 0; epoch < IterativeEpochs; epoch++ {
+				lr.Train(IterativeLearnRate, features, labels)
+			}
+			for _, i := range missing[n] {
+				predict := lr.Predict(row(i))
+				change = math.Max(change, math.Abs(predict-values[n][i]))
+				values[n][i] = predict
+			}
+		}
+		if change < tol {
+			break
+		}
+	}
+	for n, v := range vectors {
+		for _, i := range missing[n] {
+			v.setCell(i, numberCell(v.t, values[n][i]*stds[n]+means[n]))
+		}
+	}
+	return round, nil
+}
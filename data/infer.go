@@ -0,0 +1,184 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"ml/constant"
+	"strconv"
+	"time"
+)
+
+// DefaultInferRows default sample rows for column inference
+const DefaultInferRows = 1000
+
+// TimeLayouts layouts tried in order when infer time columns
+var TimeLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	time.RFC3339Nano,
+	"02/01/2006",
+	"01/02/2006",
+	"02-01-2006",
+	"02.01.2006",
+	"2006-01",
+	"Jan 2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+	time.RFC1123,
+	time.RFC1123Z,
+}
+
+// LoadFromCSVInfer read data from csv, columns not defined by AddColumn
+// are inferred from the header row and the first sampleRows rows
+func (d *Data) LoadFromCSVInfer(r io.Reader, sampleRows int) error {
+	return d.LoadFromCSVOptions(r, CSVOptions{Infer: true, InferRows: sampleRows})
+}
+
+func (d *Data) loadCSVInfer(cr *csv.Reader, dl *dialect, sampleRows int, byName bool) error {
+	if sampleRows <= 0 {
+		sampleRows = DefaultInferRows
+	}
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return constant.ErrNoHeader
+		}
+		return err
+	}
+	samples := make([][]string, 0, sampleRows)
+	for len(samples) < sampleRows {
+		row, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		samples = append(samples, row)
+	}
+	var positions map[int]int
+	if byName {
+		positions, err = d.inferByName(header, samples, dl)
+		if err != nil {
+			return err
+		}
+	} else {
+		for idx, name := range header {
+			if _, ok := d.columnsByIndex[idx]; ok {
+				continue
+			}
+			d.AddColumn(dl.inferColumn(name, idx, idx, samples))
+		}
+	}
+	cols := d.Columns()
+	for i, row := range samples {
+		if err := d.addRow(cols, positions, dl, i+2, row); err != nil {
+			return err
+		}
+	}
+	return d.readCSV(cr, positions, dl, len(samples)+1)
+}
+
+// inferByName bind declared columns by header name, other header columns
+// are inferred and indexed after declared columns, return field positions
+// of all columns
+func (d *Data) inferByName(header []string, samples [][]string, dl *dialect) (map[int]int, error) {
+	positions, err := bindHeader(header, d.Columns())
+	if err != nil {
+		return nil, err
+	}
+	bound := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		bound[pos] = true
+	}
+	for pos, name := range header {
+		if bound[pos] {
+			continue
+		}
+		idx := d.nextIndex()
+		d.AddColumn(dl.inferColumn(name, idx, pos, samples))
+		positions[idx] = pos
+	}
+	return positions, nil
+}
+
+// inferColumn infer column of field position by samples cleaned by
+// dialect, float is tried with decimal separator of dialect
+func (dl *dialect) inferColumn(name string, idx, pos int, samples [][]string) Column {
+	values := make([]string, 0, len(samples))
+	for _, row := range samples {
+		if pos < len(row) {
+			values = append(values, dl.clean(row[pos], ColumnString))
+		}
+	}
+	col := InferColumn(name, idx, values)
+	if col.t != ColumnString || dl == nil || dl.decimal == 0 || dl.decimal == '.' {
+		return col
+	}
+	for i, str := range values {
+		values[i] = dl.clean(str, ColumnFloat)
+	}
+	if inferAll(values, isFloat) {
+		return NewFloatColumn(col.name, idx)
+	}
+	return col
+}
+
+// InferColumn create column by sample values, empty values are ignored
+func InferColumn(name string, idx int, values []string) Column {
+	if len(name) == 0 {
+		name = fmt.Sprintf("column_%d", idx)
+	}
+	switch {
+	case inferAll(values, isInt):
+		return NewIntColumn(name, idx)
+	case inferAll(values, isFloat):
+		return NewFloatColumn(name, idx)
+	}
+	if layout, ok := inferTimeLayout(values); ok {
+		return NewTimeLayoutColumn(name, idx, layout)
+	}
+	return NewStringColumn(name, idx)
+}
+
+func inferAll(values []string, fn func(string) bool) bool {
+	var valid int
+	for _, str := range values {
+		if len(str) == 0 {
+			continue
+		}
+		if !fn(str) {
+			return false
+		}
+		valid++
+	}
+	return valid > 0
+}
+
+func isInt(str string) bool {
+	_, err := strconv.ParseInt(str, 10, 64)
+	return err == nil
+}
+
+func isFloat(str string) bool {
+	_, err := strconv.ParseFloat(str, 64)
+	return err == nil
+}
+
+func inferTimeLayout(values []string) (string, bool) {
+	for _, layout := range TimeLayouts {
+		ok := inferAll(values, func(str string) bool {
+			_, err := time.Parse(layout, str)
+			return err == nil
+		})
+		if ok {
+			return layout, true
+		}
+	}
+	return "", false
+}
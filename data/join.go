@@ -0,0 +1,157 @@
+package data
+
+import (
+	"fmt"
+	"ml/constant"
+	"strings"
+)
+
+// JoinType type of join
+type JoinType int
+
+const (
+	// JoinInner keep rows matched in both data
+	JoinInner JoinType = iota
+	// JoinLeft keep all rows of left data
+	JoinLeft
+	// JoinOuter keep all rows of both data
+	JoinOuter
+)
+
+// JoinOptions options of Join
+type JoinOptions struct {
+	How JoinType
+	// On key column names, must exist in both data with the same type
+	On []string
+	// LeftSuffix and RightSuffix are appended to non-key columns exist in
+	// both data, default is _left and _right
+	LeftSuffix  string
+	RightSuffix string
+}
+
+// Join join right data on key columns, rows with missing keys never match,
+// result contains key columns, left columns and right columns by order,
+// values not matched are missing
+func (d *Data) Join(right *Data, opt JoinOptions) (*Data, error) {
+	if len(opt.LeftSuffix) == 0 {
+		opt.LeftSuffix = "_left"
+	}
+	if len(opt.RightSuffix) == 0 {
+		opt.RightSuffix = "_right"
+	}
+	if len(opt.On) == 0 {
+		return nil, constant.ErrJoinKey
+	}
+	leftKeys := make([]*Column, len(opt.On))
+	rightKeys := make([]*Column, len(opt.On))
+	isKey := make(map[string]bool, len(opt.On))
+	for i, name := range opt.On {
+		leftKeys[i] = d.GetColumnByName(name)
+		rightKeys[i] = right.GetColumnByName(name)
+		if leftKeys[i] == nil || rightKeys[i] == nil || leftKeys[i].t != rightKeys[i].t {
+			return nil, fmt.Errorf("%s: %w", name, constant.ErrJoinKey)
+		}
+		isKey[name] = true
+	}
+
+	// match rows
+	index := make(map[string][]int)
+	for i := 0; i < right.rows; i++ {
+		key, ok := joinKey(right, rightKeys, i)
+		if ok {
+			index[key] = append(index[key], i)
+		}
+	}
+	var lrows, rrows []int
+	matched := make([]bool, right.rows)
+	for i := 0; i < d.rows; i++ {
+		key, ok := joinKey(d, leftKeys, i)
+		var rows []int
+		if ok {
+			rows = index[key]
+		}
+		for _, j := range rows {
+			lrows = append(lrows, i)
+			rrows = append(rrows, j)
+			matched[j] = true
+		}
+		if len(rows) == 0 && opt.How != JoinInner {
+			lrows = append(lrows, i)
+			rrows = append(rrows, -1)
+		}
+	}
+	if opt.How == JoinOuter {
+		for j, ok := range matched {
+			if !ok {
+				lrows = append(lrows, -1)
+				rrows = append(rrows, j)
+			}
+		}
+	}
+
+	// build result
+	ret := NewData()
+	add := func(col Column, build func(v *vector, i int)) {
+		col.index = len(ret.columnsByIndex)
+		ret.AddColumn(col)
+		v := newVector(col.t, len(lrows))
+		for i := range lrows {
+			build(v, i)
+		}
+		ret.vectors[col.index] = v
+	}
+	from := func(src *vector, rows []int) func(v *vector, i int) {
+		return func(v *vector, i int) {
+			if rows[i] < 0 {
+				v.appendNull()
+				return
+			}
+			v.appendFrom(src, rows[i])
+		}
+	}
+	for k := range opt.On {
+		lv, rv := d.vector(leftKeys[k]), right.vector(rightKeys[k])
+		add(*leftKeys[k], func(v *vector, i int) {
+			if lrows[i] < 0 {
+				v.appendFrom(rv, rrows[i])
+				return
+			}
+			v.appendFrom(lv, lrows[i])
+		})
+	}
+	for _, col := range d.Columns() {
+		if isKey[col.name] {
+			continue
+		}
+		c := *col
+		if right.GetColumnByName(col.name) != nil {
+			c.name += opt.LeftSuffix
+		}
+		add(c, from(d.vector(col), lrows))
+	}
+	for _, col := range right.Columns() {
+		if isKey[col.name] {
+			continue
+		}
+		c := *col
+		if d.GetColumnByName(col.name) != nil {
+			c.name += opt.RightSuffix
+		}
+		add(c, from(right.vector(col), rrows))
+	}
+	ret.rows = len(lrows)
+	ret.loaded = true
+	return ret, nil
+}
+
+func joinKey(d *Data, keys []*Column, i int) (string, bool) {
+	var buf strings.Builder
+	for _, col := range keys {
+		v := d.vector(col)
+		if v.isNull(i) {
+			return "", false
+		}
+		v.writeKey(&buf, i)
+	}
+	return buf.String(), true
+}
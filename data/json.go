@@ -0,0 +1,242 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"ml/constant"
+	"strconv"
+	"time"
+)
+
+// LoadFromJSON read data from json array of objects, keys are mapped to
+// columns by name, columns are inferred when none is declared
+func (d *Data) LoadFromJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	if err := d.loadJSON(dec); err != nil {
+		return err
+	}
+	return expectDelim(dec, ']')
+}
+
+// LoadFromJSONL read data from json lines, one object per line, keys are
+// mapped to columns by name, columns are inferred when none is declared
+func (d *Data) LoadFromJSONL(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return d.loadJSON(dec)
+}
+
+// loadJSON read objects until the end of array or stream, null and "" are
+// missing, true and false are 1 and 0
+func (d *Data) loadJSON(dec *json.Decoder) error {
+	d.parseErrors = nil
+	var objects []map[string]string
+	if len(d.columnsByIndex) == 0 {
+		var keys []string
+		seen := make(map[string]bool)
+		for dec.More() && len(objects) < DefaultInferRows {
+			object, order, err := readObject(dec)
+			if err != nil {
+				return err
+			}
+			for _, key := range order {
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+			objects = append(objects, object)
+		}
+		if len(keys) == 0 {
+			return constant.ErrNoColumns
+		}
+		for idx, key := range keys {
+			values := make([]string, len(objects))
+			for i, object := range objects {
+				values[i] = object[key]
+			}
+			d.AddColumn(InferColumn(key, idx, values))
+		}
+	}
+	cols := d.Columns()
+	row := make([]string, d.maxIndex()+1)
+	var record int
+	add := func(object map[string]string) error {
+		record++
+		for _, col := range cols {
+			row[col.index] = object[col.name]
+		}
+		return d.addRow(cols, nil, nil, record, row)
+	}
+	for _, object := range objects {
+		if err := add(object); err != nil {
+			return err
+		}
+	}
+	for dec.More() {
+		object, _, err := readObject(dec)
+		if err != nil {
+			return err
+		}
+		if err := add(object); err != nil {
+			return err
+		}
+	}
+	d.loaded = true
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expect %s, got %v: %w", delim, token, constant.ErrValueType)
+	}
+	return nil
+}
+
+// readObject read one object as cell strings and keys in order
+func readObject(dec *json.Decoder) (map[string]string, []string, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, nil, err
+	}
+	ret := make(map[string]string)
+	var keys []string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+		str, err := jsonCell(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		ret[key] = str
+		keys = append(keys, key)
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, nil, err
+	}
+	return ret, keys, nil
+}
+
+func jsonCell(raw json.RawMessage) (string, error) {
+	switch {
+	case len(raw) == 0:
+		return "", nil
+	case raw[0] == '"':
+		var str string
+		err := json.Unmarshal(raw, &str)
+		return str, err
+	case string(raw) == "null":
+		return "", nil
+	case string(raw) == "true":
+		return "1", nil
+	case string(raw) == "false":
+		return "0", nil
+	default:
+		return string(raw), nil
+	}
+}
+
+// WriteJSON write data as json array of objects, missing values and
+// infinite or NaN floats are null, times are formatted by timeLayout or by column when timeLayout is empty
+func (d *Data) WriteJSON(w io.Writer, timeLayout string) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+	for i := 0; i < d.rows; i++ {
+		if i > 0 {
+			if _, err := bw.WriteString(","); err != nil {
+				return err
+			}
+		}
+		if err := d.writeJSONObject(bw, i, timeLayout); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString("]\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// WriteJSONL write data as json lines, see WriteJSON
+func (d *Data) WriteJSONL(w io.Writer, timeLayout string) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < d.rows; i++ {
+		if err := d.writeJSONObject(bw, i, timeLayout); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func (d *Data) writeJSONObject(w *bufio.Writer, i int, timeLayout string) error {
+	buf := []byte{'{'}
+	for n, col := range d.Columns() {
+		if n > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendQuote(buf, col.name)
+		buf = append(buf, ':')
+		v := d.vectors[col.index]
+		if v.isNull(i) || (v.t == ColumnFloat && (math.IsInf(v.f[i], 0) || math.IsNaN(v.f[i]))) {
+			buf = append(buf, "null"...)
+			continue
+		}
+		switch v.t {
+		case ColumnFloat:
+			value, err := json.Marshal(v.f[i])
+			if err != nil {
+				return err
+			}
+			buf = append(buf, value...)
+		case ColumnInt:
+			buf = strconv.AppendInt(buf, v.i[i], 10)
+		case ColumnString:
+			value, err := json.Marshal(v.s[i])
+			if err != nil {
+				return err
+			}
+			buf = append(buf, value...)
+		case ColumnTime:
+			value, err := json.Marshal(formatTime(col, v.ts[i], timeLayout))
+			if err != nil {
+				return err
+			}
+			buf = append(buf, value...)
+		}
+	}
+	buf = append(buf, '}')
+	_, err := w.Write(buf)
+	return err
+}
+
+func formatTime(col *Column, t time.Time, layout string) string {
+	if len(layout) > 0 {
+		return t.Format(layout)
+	}
+	if col.timeFormat == nil {
+		return t.Format(time.RFC3339)
+	}
+	return col.timeFormat(t)
+}
@@ -0,0 +1,124 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"sort"
+)
+
+// OutlierMethod how to detect outliers
+type OutlierMethod int
+
+const (
+	// OutlierIQR outside [q25-k*iqr, q75+k*iqr], k is usually 1.5
+	OutlierIQR OutlierMethod = iota
+	// OutlierZScore outside mean±k*std, k is usually 3
+	OutlierZScore
+	// OutlierMAD modified z-score 0.6745*|x-median|/mad greater than k,
+	// k is usually 3.5
+	OutlierMAD
+)
+
+// Outliers outliers found by DetectOutliers, values outside [Lower, Upper]
+// are outliers
+type Outliers struct {
+	Lower float64
+	Upper float64
+	Rows  []int
+}
+
+// DetectOutliers detect outliers of int or float column by method with
+// threshold k, missing values are never outliers
+func (d *Data) DetectOutliers(c *Column, method OutlierMethod, k float64) (*Outliers, error) {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return nil, err
+	}
+	ret := &Outliers{}
+	switch method {
+	case OutlierIQR:
+		q25, q75 := quantile(values, .25), quantile(values, .75)
+		ret.Lower = q25 - k*(q75-q25)
+		ret.Upper = q75 + k*(q75-q25)
+	case OutlierZScore:
+		s := d.Stats(c)
+		ret.Lower = s.Mean - k*s.Std
+		ret.Upper = s.Mean + k*s.Std
+	case OutlierMAD:
+		median := quantile(values, .5)
+		diffs := make([]float64, len(values))
+		for i, x := range values {
+			diffs[i] = math.Abs(x - median)
+		}
+		sort.Float64s(diffs)
+		mad := quantile(diffs, .5)
+		ret.Lower = median - k*mad/0.6745
+		ret.Upper = median + k*mad/0.6745
+	default:
+		return nil, fmt.Errorf("outlier method %d: %w", method, constant.ErrInvalidParam)
+	}
+	v := d.vector(c)
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		if x := v.number(i); x < ret.Lower || x > ret.Upper {
+			ret.Rows = append(ret.Rows, i)
+		}
+	}
+	return ret, nil
+}
+
+// Clip clip int or float column into [lower, upper] in place, bounds of
+// int column are rounded inward
+func (d *Data) Clip(c *Column, lower, upper float64) error {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return fmt.Errorf("clip %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	if lower > upper {
+		return fmt.Errorf("clip [%f, %f]: %w", lower, upper, constant.ErrInvalidParam)
+	}
+	v := d.vector(c)
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		if v.t == ColumnInt {
+			lo, hi := int64(math.Ceil(lower)), int64(math.Floor(upper))
+			if v.i[i] < lo {
+				v.i[i] = lo
+			} else if v.i[i] > hi {
+				v.i[i] = hi
+			}
+			continue
+		}
+		v.f[i] = math.Min(math.Max(v.f[i], lower), upper)
+	}
+	return nil
+}
+
+// Winsorize clip int or float column into its lower and upper percentile,
+// percentile is in [0, 1]
+func (d *Data) Winsorize(c *Column, lower, upper float64) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	return d.Clip(c, quantile(values, lower), quantile(values, upper))
+}
+
+// DropRows get new data without rows
+func (d *Data) DropRows(rows []int) *Data {
+	drop := make(map[int]bool, len(rows))
+	for _, i := range rows {
+		drop[i] = true
+	}
+	keep := make([]int, 0, d.rows)
+	for i := 0; i < d.rows; i++ {
+		if !drop[i] {
+			keep = append(keep, i)
+		}
+	}
+	return d.take(keep)
+}
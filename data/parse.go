@@ -0,0 +1,42 @@
+package data
+
+import "fmt"
+
+// ParseMode how to handle cells which can not be parsed
+type ParseMode int
+
+const (
+	// ParseStrict stop loading on the first bad cell
+	ParseStrict ParseMode = iota
+	// ParseLenient set bad cells to missing and record them in ParseErrors
+	ParseLenient
+)
+
+// CellError parse error of one cell
+type CellError struct {
+	Record int    // 1-based record number in source, header included
+	Column string // column name
+	Index  int    // column index
+	Value  string // raw value
+	Err    error
+}
+
+func (e *CellError) Error() string {
+	return fmt.Sprintf("record %d, column %s(%d): can not parse %q: %v",
+		e.Record, e.Column, e.Index, e.Value, e.Err)
+}
+
+// Unwrap get the underlying error
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// SetParseMode set parse mode for next loading, default is ParseStrict
+func (d *Data) SetParseMode(mode ParseMode) {
+	d.mode = mode
+}
+
+// ParseErrors get bad cells of the last loading found in ParseLenient mode
+func (d *Data) ParseErrors() []*CellError {
+	return d.parseErrors
+}
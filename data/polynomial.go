@@ -0,0 +1,89 @@
+package data
+
+import (
+	"fmt"
+	"ml/constant"
+	"strconv"
+	"strings"
+)
+
+// AddPolynomial add float columns of polynomial terms with degree 2 to
+// degree of int or float columns, terms are ordered by degree then by
+// order of cols and named like a^2, a*b and a^2*b, only products of
+// distinct columns are added when interactionOnly, terms with any missing
+// factor are missing
+func (d *Data) AddPolynomial(cols []*Column, degree int, interactionOnly bool) ([]*Column, error) {
+	if degree < 2 {
+		return nil, fmt.Errorf("polynomial degree %d: %w", degree, constant.ErrInvalidParam)
+	}
+	vectors := make([]*vector, len(cols))
+	for i, col := range cols {
+		if col.t != ColumnInt && col.t != ColumnFloat {
+			return nil, fmt.Errorf("polynomial %s(%s): %w", col.name, col.t, constant.ErrValueType)
+		}
+		vectors[i] = d.vector(col)
+	}
+	var ret []*Column
+	for n := 2; n <= degree; n++ {
+		for _, term := range combinations(len(cols), n, !interactionOnly) {
+			v := newVector(ColumnFloat, d.rows)
+		next:
+			for i := 0; i < d.rows; i++ {
+				value := 1.
+				for _, j := range term {
+					if vectors[j].isNull(i) {
+						v.appendNull()
+						continue next
+					}
+					value *= vectors[j].number(i)
+				}
+				v.appendFloat(value)
+			}
+			col := d.AddColumn(NewFloatColumn(termName(cols, term), d.nextIndex()))
+			d.vectors[col.index] = v
+			ret = append(ret, col)
+		}
+	}
+	return ret, nil
+}
+
+// combinations get ascending index combinations of size k from n indexes
+func combinations(n, k int, repeat bool) [][]int {
+	var ret [][]int
+	term := make([]int, 0, k)
+	var walk func(start int)
+	walk = func(start int) {
+		if len(term) == k {
+			ret = append(ret, append([]int(nil), term...))
+			return
+		}
+		for i := start; i < n; i++ {
+			term = append(term, i)
+			if repeat {
+				walk(i)
+			} else {
+				walk(i + 1)
+			}
+			term = term[:len(term)-1]
+		}
+	}
+	walk(0)
+	return ret
+}
+
+func termName(cols []*Column, term []int) string {
+	var parts []string
+	for i := 0; i < len(term); {
+		j := i
+		for j < len(term) && term[j] == term[i] {
+			j++
+		}
+		name := cols[term[i]].name
+		if j-i > 1 {
+			name += "^" + strconv.Itoa(j-i)
+		}
+		parts = append(parts, name)
+		i = j
+	}
+	return strings.Join(parts, "*")
+}
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+)
+
+// lambda search range and tolerance of power transforms
+const (
+	powerLambdaMin = -5
+	powerLambdaMax = 5
+	powerLambdaTol = 1e-6
+)
+
+// Log1pTransformer transform by log(1+x), values not greater than -1 are
+// transformed to missing
+type Log1pTransformer struct{}
+
+// Fit nothing to fit
+func (t *Log1pTransformer) Fit(d *Data, c *Column) error {
+	_, err := fitValues(d, c)
+	return err
+}
+
+// Transform transform value
+func (t *Log1pTransformer) Transform(x float64) float64 {
+	if x <= -1 {
+		return math.NaN()
+	}
+	return math.Log1p(x)
+}
+
+// Inverse inverse value
+func (t *Log1pTransformer) Inverse(x float64) float64 {
+	return math.Expm1(x)
+}
+
+// SqrtTransformer transform by sqrt(x), negative values are transformed
+// to missing
+type SqrtTransformer struct{}
+
+// Fit nothing to fit
+func (t *SqrtTransformer) Fit(d *Data, c *Column) error {
+	_, err := fitValues(d, c)
+	return err
+}
+
+// Transform transform value
+func (t *SqrtTransformer) Transform(x float64) float64 {
+	return math.Sqrt(x)
+}
+
+// Inverse inverse value
+func (t *SqrtTransformer) Inverse(x float64) float64 {
+	return x * x
+}
+
+// BoxCoxTransformer transform positive values by (x^λ-1)/λ, or log(x)
+// when λ is 0, Fit finds λ by maximum likelihood
+type BoxCoxTransformer struct {
+	Lambda float64 `json:"lambda"`
+}
+
+// Fit fit λ, all values must be positive
+func (t *BoxCoxTransformer) Fit(d *Data, c *Column) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	if values[0] <= 0 {
+		return fmt.Errorf("box-cox %s min %f: %w", c.name, values[0], constant.ErrInvalidParam)
+	}
+	var logs float64
+	for _, x := range values {
+		logs += math.Log(x)
+	}
+	t.Lambda = maximize(func(lambda float64) float64 {
+		y := make([]float64, len(values))
+		for i, x := range values {
+			y[i] = boxCox(x, lambda)
+		}
+		return (lambda-1)*logs - float64(len(y))/2*math.Log(variance(y))
+	})
+	return nil
+}
+
+// Transform transform value, non-positive values are transformed to missing
+func (t *BoxCoxTransformer) Transform(x float64) float64 {
+	if x <= 0 {
+		return math.NaN()
+	}
+	return boxCox(x, t.Lambda)
+}
+
+// Inverse inverse value
+func (t *BoxCoxTransformer) Inverse(x float64) float64 {
+	if math.Abs(t.Lambda) < powerLambdaTol {
+		return math.Exp(x)
+	}
+	return math.Pow(t.Lambda*x+1, 1/t.Lambda)
+}
+
+// YeoJohnsonTransformer extend Box-Cox to any value:
+// ((x+1)^λ-1)/λ for x >= 0 and -((1-x)^(2-λ)-1)/(2-λ) for x < 0, Fit finds
+// λ by maximum likelihood
+type YeoJohnsonTransformer struct {
+	Lambda float64 `json:"lambda"`
+}
+
+// Fit fit λ
+func (t *YeoJohnsonTransformer) Fit(d *Data, c *Column) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	var logs float64
+	for _, x := range values {
+		if x >= 0 {
+			logs += math.Log1p(x)
+		} else {
+			logs -= math.Log1p(-x)
+		}
+	}
+	t.Lambda = maximize(func(lambda float64) float64 {
+		y := make([]float64, len(values))
+		for i, x := range values {
+			y[i] = yeoJohnson(x, lambda)
+		}
+		return (lambda-1)*logs - float64(len(y))/2*math.Log(variance(y))
+	})
+	return nil
+}
+
+// Transform transform value
+func (t *YeoJohnsonTransformer) Transform(x float64) float64 {
+	return yeoJohnson(x, t.Lambda)
+}
+
+// Inverse inverse value
+func (t *YeoJohnsonTransformer) Inverse(x float64) float64 {
+	lambda := t.Lambda
+	if x >= 0 {
+		if math.Abs(lambda) < powerLambdaTol {
+			return math.Expm1(x)
+		}
+		return math.Pow(lambda*x+1, 1/lambda) - 1
+	}
+	if math.Abs(lambda-2) < powerLambdaTol {
+		return -math.Expm1(-x)
+	}
+	return 1 - math.Pow(1-(2-lambda)*x, 1/(2-lambda))
+}
+
+func boxCox(x, lambda float64) float64 {
+	if math.Abs(lambda) < powerLambdaTol {
+		return math.Log(x)
+	}
+	return (math.Pow(x, lambda) - 1) / lambda
+}
+
+func yeoJohnson(x, lambda float64) float64 {
+	if x >= 0 {
+		if math.Abs(lambda) < powerLambdaTol {
+			return math.Log1p(x)
+		}
+		return (math.Pow(x+1, lambda) - 1) / lambda
+	}
+	if math.Abs(lambda-2) < powerLambdaTol {
+		return -math.Log1p(-x)
+	}
+	return -(math.Pow(1-x, 2-lambda) - 1) / (2 - lambda)
+}
+
+// variance population variance
+func variance(values []float64) float64 {
+	var total float64
+	for _, x := range values {
+		total += x
+	}
+	mean := total / float64(len(values))
+	var ret float64
+	for _, x := range values {
+		ret += (x - mean) * (x - mean)
+	}
+	return ret / float64(len(values))
+}
+
+// maximize find λ maximizing unimodal fn by golden section search
+func maximize(fn func(float64) float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+	a, b := float64(powerLambdaMin), float64(powerLambdaMax)
+	c := b - ratio*(b-a)
+	d := a + ratio*(b-a)
+	fc, fd := fn(c), fn(d)
+	for b-a > powerLambdaTol {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = fn(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = fn(d)
+		}
+	}
+	return (a + b) / 2
+}
@@ -0,0 +1,78 @@
+package data
+
+import "time"
+
+// Row view of one row in data
+type Row struct {
+	d     *Data
+	index int
+}
+
+// Row get row view by index
+func (d *Data) Row(i int) Row {
+	return Row{d: d, index: i}
+}
+
+// Index get index of row in data
+func (r Row) Index() int {
+	return r.index
+}
+
+func (r Row) vector(name string) *vector {
+	col := r.d.columnsByName[name]
+	if col == nil {
+		return nil
+	}
+	return r.d.vectors[col.index]
+}
+
+// IsNull check value of column is missing, unknown column is missing
+func (r Row) IsNull(name string) bool {
+	v := r.vector(name)
+	return v == nil || v.isNull(r.index)
+}
+
+// Float get numeric value of int or float column, 0 for missing
+func (r Row) Float(name string) float64 {
+	v := r.vector(name)
+	if v == nil {
+		return 0
+	}
+	return v.number(r.index)
+}
+
+// Int get value of int column, 0 for missing
+func (r Row) Int(name string) int64 {
+	v := r.vector(name)
+	if v == nil || v.t != ColumnInt || v.isNull(r.index) {
+		return 0
+	}
+	return v.i[r.index]
+}
+
+// String get value of string column, empty for missing
+func (r Row) String(name string) string {
+	v := r.vector(name)
+	if v == nil || v.t != ColumnString || v.isNull(r.index) {
+		return ""
+	}
+	return v.s[r.index]
+}
+
+// Time get value of time column, zero time for missing
+func (r Row) Time(name string) time.Time {
+	v := r.vector(name)
+	if v == nil || v.t != ColumnTime || v.isNull(r.index) {
+		return time.Time{}
+	}
+	return v.ts[r.index]
+}
+
+// Cell get value of column as cell, nil for unknown column
+func (r Row) Cell(name string) *Cell {
+	col := r.d.columnsByName[name]
+	if col == nil {
+		return nil
+	}
+	return r.d.vectors[col.index].cell(r.index, col)
+}
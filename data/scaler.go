@@ -0,0 +1,194 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"ml/constant"
+	"sort"
+)
+
+// Transformer fitted numeric transform, exported fields are the fitted
+// state which can be saved by encoding/json
+type Transformer interface {
+	// Fit fit state by valid values of int or float column
+	Fit(d *Data, c *Column) error
+	// Transform transform one value
+	Transform(x float64) float64
+	// Inverse inverse transform one value, such as predictions
+	Inverse(x float64) float64
+}
+
+// Transform transform int or float column in place by fitted transformer,
+// column is converted to float, missing values are kept and NaN results
+// are missing
+func (d *Data) Transform(c *Column, t Transformer) error {
+	return d.apply(c, t.Transform)
+}
+
+// InverseTransform inverse transform int or float column in place by
+// fitted transformer, column is converted to float
+func (d *Data) InverseTransform(c *Column, t Transformer) error {
+	return d.apply(c, t.Inverse)
+}
+
+// FitTransform fit transformer by column and transform it
+func (d *Data) FitTransform(c *Column, t Transformer) error {
+	if err := t.Fit(d, c); err != nil {
+		return err
+	}
+	return d.Transform(c, t)
+}
+
+func (d *Data) apply(c *Column, fn func(float64) float64) error {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return fmt.Errorf("transform %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	v := d.vector(c)
+	v.toFloat()
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		v.f[i] = fn(v.f[i])
+		if math.IsNaN(v.f[i]) {
+			v.setNull(i, true)
+		}
+	}
+	c.t = ColumnFloat
+	return nil
+}
+
+// fitValues get sorted valid values of int or float column
+func fitValues(d *Data, c *Column) ([]float64, error) {
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return nil, fmt.Errorf("fit %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	values := d.vector(c).numbers()
+	if len(values) == 0 {
+		return nil, fmt.Errorf("fit %s: %w", c.name, constant.ErrNoValues)
+	}
+	sort.Float64s(values)
+	return values, nil
+}
+
+// scale (x-offset)/scale, scale 0 is treated as 1
+func scale(x, offset, scale float64) float64 {
+	if scale == 0 {
+		return x - offset
+	}
+	return (x - offset) / scale
+}
+
+func unscale(x, offset, scale float64) float64 {
+	if scale == 0 {
+		return x + offset
+	}
+	return x*scale + offset
+}
+
+// MinMaxScaler scale values to [0, 1] by (x-min)/(max-min)
+type MinMaxScaler struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Fit fit min and max
+func (s *MinMaxScaler) Fit(d *Data, c *Column) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	s.Min = values[0]
+	s.Max = values[len(values)-1]
+	return nil
+}
+
+// Transform scale value
+func (s *MinMaxScaler) Transform(x float64) float64 {
+	return scale(x, s.Min, s.Max-s.Min)
+}
+
+// Inverse unscale value
+func (s *MinMaxScaler) Inverse(x float64) float64 {
+	return unscale(x, s.Min, s.Max-s.Min)
+}
+
+// StandardScaler scale values by (x-mean)/std, std is sample standard
+// deviation
+type StandardScaler struct {
+	Mean float64 `json:"mean"`
+	Std  float64 `json:"std"`
+}
+
+// Fit fit mean and std
+func (s *StandardScaler) Fit(d *Data, c *Column) error {
+	if _, err := fitValues(d, c); err != nil {
+		return err
+	}
+	stats := d.Stats(c)
+	s.Mean = stats.Mean
+	s.Std = stats.Std
+	return nil
+}
+
+// Transform scale value
+func (s *StandardScaler) Transform(x float64) float64 {
+	return scale(x, s.Mean, s.Std)
+}
+
+// Inverse unscale value
+func (s *StandardScaler) Inverse(x float64) float64 {
+	return unscale(x, s.Mean, s.Std)
+}
+
+// MaxAbsScaler scale values to [-1, 1] by x/max(|x|)
+type MaxAbsScaler struct {
+	MaxAbs float64 `json:"max_abs"`
+}
+
+// Fit fit max absolute value
+func (s *MaxAbsScaler) Fit(d *Data, c *Column) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	s.MaxAbs = math.Max(math.Abs(values[0]), math.Abs(values[len(values)-1]))
+	return nil
+}
+
+// Transform scale value
+func (s *MaxAbsScaler) Transform(x float64) float64 {
+	return scale(x, 0, s.MaxAbs)
+}
+
+// Inverse unscale value
+func (s *MaxAbsScaler) Inverse(x float64) float64 {
+	return unscale(x, 0, s.MaxAbs)
+}
+
+// RobustScaler scale values by (x-median)/(q75-q25), robust to outliers
+type RobustScaler struct {
+	Median float64 `json:"median"`
+	IQR    float64 `json:"iqr"`
+}
+
+// Fit fit median and interquartile range
+func (s *RobustScaler) Fit(d *Data, c *Column) error {
+	values, err := fitValues(d, c)
+	if err != nil {
+		return err
+	}
+	s.Median = quantile(values, .5)
+	s.IQR = quantile(values, .75) - quantile(values, .25)
+	return nil
+}
+
+// Transform scale value
+func (s *RobustScaler) Transform(x float64) float64 {
+	return scale(x, s.Median, s.IQR)
+}
+
+// Inverse unscale value
+func (s *RobustScaler) Inverse(x float64) float64 {
+	return unscale(x, s.Median, s.IQR)
+}
@@ -0,0 +1,119 @@
+package data
+
+import "sort"
+
+// SortKey sort key of SortBy
+type SortKey struct {
+	Column *Column
+	Desc   bool
+}
+
+// Asc ascending sort key
+func Asc(c *Column) SortKey {
+	return SortKey{Column: c}
+}
+
+// Desc descending sort key
+func Desc(c *Column) SortKey {
+	return SortKey{Column: c, Desc: true}
+}
+
+// take create new data with rows by index
+func (d *Data) take(rows []int) *Data {
+	ret := d.emptyLike()
+	for idx, v := range d.vectors {
+		nv := newVector(v.t, len(rows))
+		for _, i := range rows {
+			nv.appendFrom(v, i)
+		}
+		ret.vectors[idx] = nv
+	}
+	ret.rows = len(rows)
+	return ret
+}
+
+// emptyLike create new data with the same columns and no rows
+func (d *Data) emptyLike() *Data {
+	ret := NewData()
+	for _, col := range d.columnsByIndex {
+		ret.AddColumn(*col)
+	}
+	ret.loaded = d.loaded
+	ret.mode = d.mode
+	return ret
+}
+
+// Filter get rows which fn returns true
+func (d *Data) Filter(fn func(Row) bool) *Data {
+	rows := make([]int, 0, d.rows)
+	for i := 0; i < d.rows; i++ {
+		if fn(d.Row(i)) {
+			rows = append(rows, i)
+		}
+	}
+	return d.take(rows)
+}
+
+// Slice get rows in [from, to), out of range is clamped
+func (d *Data) Slice(from, to int) *Data {
+	if from < 0 {
+		from = 0
+	}
+	if to > d.rows {
+		to = d.rows
+	}
+	if to < from {
+		to = from
+	}
+	rows := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		rows = append(rows, i)
+	}
+	return d.take(rows)
+}
+
+// Head get first n rows
+func (d *Data) Head(n int) *Data {
+	return d.Slice(0, n)
+}
+
+// Tail get last n rows
+func (d *Data) Tail(n int) *Data {
+	return d.Slice(d.rows-n, d.rows)
+}
+
+// SortBy stable sort rows by keys, missing values are always placed last
+func (d *Data) SortBy(keys ...SortKey) *Data {
+	rows := make([]int, d.rows)
+	for i := range rows {
+		rows[i] = i
+	}
+	vectors := make([]*vector, len(keys))
+	for i, key := range keys {
+		vectors[i] = d.vector(key.Column)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, v := range vectors {
+			n := v.compare(rows[i], rows[j])
+			if n == 0 {
+				continue
+			}
+			if keys[k].Desc && !v.isNull(rows[i]) && !v.isNull(rows[j]) {
+				n = -n
+			}
+			return n < 0
+		}
+		return false
+	})
+	return d.take(rows)
+}
+
+// HasNull check any value of row is missing
+func (r Row) HasNull() bool {
+	for _, v := range r.d.vectors {
+		if v.isNull(r.index) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultQuantiles quantiles computed by Stats
+var DefaultQuantiles = []float64{.25, .5, .75}
+
+// DefaultTopK most frequent values kept by Stats for string column
+var DefaultTopK = 5
+
+// Quantile interpolated quantile value
+type Quantile struct {
+	P     float64
+	Value float64
+}
+
+// ValueCount count of string value
+type ValueCount struct {
+	Value string
+	Count int
+}
+
+// ColumnStats statistics of column, missing values are ignored,
+// only fields matching the column type are filled
+type ColumnStats struct {
+	Column  string
+	Type    ColumnType
+	Count   int
+	Missing int
+
+	// int and float column
+	Mean      float64
+	Std       float64 // sample standard deviation
+	Variance  float64 // sample variance
+	Min       float64
+	Max       float64
+	Quantiles []Quantile
+	Skew      float64
+	Kurtosis  float64 // excess kurtosis
+
+	// time column
+	MinTime time.Time
+	MaxTime time.Time
+
+	// string column
+	Unique int
+	Top    []ValueCount
+
+	timeFormat func(time.Time) string
+}
+
+// Stats get statistics of column
+func (d *Data) Stats(c *Column) *ColumnStats {
+	v := d.vector(c)
+	ret := &ColumnStats{
+		Column:     c.name,
+		Type:       c.t,
+		timeFormat: c.timeFormat,
+	}
+	ret.Missing = v.nulls()
+	ret.Count = v.n - ret.Missing
+	if ret.Count == 0 {
+		return ret
+	}
+	switch c.t {
+	case ColumnInt, ColumnFloat:
+		ret.fillNumber(v)
+	case ColumnTime:
+		ret.fillTime(v)
+	case ColumnString:
+		ret.fillString(v)
+	}
+	return ret
+}
+
+func (s *ColumnStats) fillNumber(v *vector) {
+	values := make([]float64, 0, s.Count)
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			values = append(values, v.number(i))
+		}
+	}
+	sort.Float64s(values)
+	n := float64(len(values))
+	var total float64
+	for _, x := range values {
+		total += x
+	}
+	s.Mean = total / n
+	var m2, m3, m4 float64
+	for _, x := range values {
+		d := x - s.Mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	if len(values) > 1 {
+		s.Variance = m2 / (n - 1)
+		s.Std = math.Sqrt(s.Variance)
+	}
+	m2 /= n
+	m3 /= n
+	m4 /= n
+	if m2 > 0 {
+		s.Skew = m3 / math.Pow(m2, 1.5)
+		s.Kurtosis = m4/(m2*m2) - 3
+	}
+	s.Min = values[0]
+	s.Max = values[len(values)-1]
+	s.Quantiles = make([]Quantile, len(DefaultQuantiles))
+	for i, p := range DefaultQuantiles {
+		s.Quantiles[i] = Quantile{P: p, Value: quantile(values, p)}
+	}
+}
+
+func (s *ColumnStats) fillTime(v *vector) {
+	first := true
+	for i := 0; i < v.n; i++ {
+		if v.isNull(i) {
+			continue
+		}
+		t := v.ts[i]
+		if first || t.Before(s.MinTime) {
+			s.MinTime = t
+		}
+		if first || t.After(s.MaxTime) {
+			s.MaxTime = t
+		}
+		first = false
+	}
+}
+
+func (s *ColumnStats) fillString(v *vector) {
+	counts := make(map[string]int)
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			counts[v.s[i]]++
+		}
+	}
+	s.Unique = len(counts)
+	top := make([]ValueCount, 0, len(counts))
+	for k, n := range counts {
+		top = append(top, ValueCount{Value: k, Count: n})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Value < top[j].Value
+	})
+	if len(top) > DefaultTopK {
+		top = top[:DefaultTopK]
+	}
+	s.Top = top
+}
+
+// quantile linear interpolated quantile of sorted values
+func quantile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := p * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo < 0 {
+		return sorted[0]
+	}
+	if hi >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+// Quantile get quantile of value, NaN if not computed
+func (s *ColumnStats) Quantile(p float64) float64 {
+	for _, q := range s.Quantiles {
+		if q.P == p {
+			return q.Value
+		}
+	}
+	return math.NaN()
+}
+
+func (s *ColumnStats) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "valid: %d\nmissing: %d\n", s.Count, s.Missing)
+	if s.Count == 0 {
+		return buf.String()
+	}
+	switch s.Type {
+	case ColumnInt, ColumnFloat:
+		fmt.Fprintf(&buf, "mean: %f\nstd dev: %f\nvariance: %f\n", s.Mean, s.Std, s.Variance)
+		fmt.Fprintf(&buf, "min: %s", formatNumber(s.Type, s.Min))
+		for _, q := range s.Quantiles {
+			fmt.Fprintf(&buf, "; %g%%: %f", q.P*100, q.Value)
+		}
+		fmt.Fprintf(&buf, "; max: %s\n", formatNumber(s.Type, s.Max))
+		fmt.Fprintf(&buf, "skew: %f\nkurtosis: %f\n", s.Skew, s.Kurtosis)
+	case ColumnTime:
+		fmt.Fprintf(&buf, "min: %s\nmax: %s\n", s.formatTime(s.MinTime), s.formatTime(s.MaxTime))
+	case ColumnString:
+		fmt.Fprintf(&buf, "uniq: %d\n", s.Unique)
+		top := make([]string, len(s.Top))
+		for i, vc := range s.Top {
+			top[i] = fmt.Sprintf("%s(%d)", vc.Value, vc.Count)
+		}
+		fmt.Fprintf(&buf, "top: %s\n", strings.Join(top, ", "))
+	}
+	return buf.String()
+}
+
+func (s *ColumnStats) formatTime(t time.Time) string {
+	if s.timeFormat == nil {
+		return t.String()
+	}
+	return s.timeFormat(t)
+}
+
+func formatNumber(t ColumnType, n float64) string {
+	if t == ColumnInt {
+		return fmt.Sprintf("%d", int64(n))
+	}
+	return fmt.Sprintf("%f", n)
+}
+
+// Statistics statistics by column
+func (d *Data) Statistics(c *Column) string {
+	if !d.loaded {
+		return ""
+	}
+	return d.Stats(c).String()
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"ml/constant"
+)
+
+// DefaultChunkSize rows buffered by Reader.Next
+const DefaultChunkSize = 1024
+
+// Reader read csv stream by row or by chunk
+type Reader struct {
+	cr      *csv.Reader
+	columns []*Column
+	mode    ParseMode
+	header  bool
+	byName  bool
+	record  int
+
+	dialect   *dialect
+	positions map[int]int
+
+	chunk  *Data
+	offset int
+	// err error after rows of the last chunk, returned by the next read
+	err error
+}
+
+// NewCSVReader create stream reader with column definitions
+func NewCSVReader(r io.Reader, skipHeader bool, cols ...Column) *Reader {
+	ret, _ := NewCSVReaderOptions(r, CSVOptions{SkipHeader: skipHeader}, cols...)
+	return ret
+}
+
+// NewCSVReaderOptions create stream reader with csv options and column
+// definitions, Infer of options is not supported and returns error
+func NewCSVReaderOptions(r io.Reader, opt CSVOptions, cols ...Column) (*Reader, error) {
+	if opt.Infer {
+		return nil, fmt.Errorf("stream with infer: %w", constant.ErrInvalidParam)
+	}
+	cr, err := newCSVReader(r, opt)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]*Column, len(cols))
+	for i := range cols {
+		col := cols[i]
+		columns[i] = &col
+	}
+	return &Reader{
+		cr:      cr,
+		columns: columns,
+		header:  opt.SkipHeader || opt.ByName,
+		byName:  opt.ByName,
+		dialect: newDialect(opt),
+	}, nil
+}
+
+// SetParseMode set parse mode, bad cells of chunk are in Data.ParseErrors
+// in ParseLenient mode
+func (r *Reader) SetParseMode(mode ParseMode) {
+	r.mode = mode
+}
+
+// Next read next row, return io.EOF when no more rows
+func (r *Reader) Next() (Row, error) {
+	if r.chunk == nil || r.offset >= r.chunk.Total() {
+		chunk, err := r.NextChunk(DefaultChunkSize)
+		if err != nil {
+			return Row{}, err
+		}
+		r.chunk = chunk
+		r.offset = 0
+	}
+	row := r.chunk.Row(r.offset)
+	r.offset++
+	return row, nil
+}
+
+// NextChunk read at most size rows into new data, return io.EOF when no
+// more rows, rows before a bad record are returned and the error is
+// returned by the next read
+func (r *Reader) NextChunk(size int) (*Data, error) {
+	if len(r.columns) == 0 {
+		return nil, constant.ErrNoColumns
+	}
+	if r.err != nil {
+		err := r.err
+		r.err = nil
+		return nil, err
+	}
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if r.header {
+		r.header = false
+		header, err := r.cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.record++
+		if r.byName {
+			r.positions, err = bindHeader(header, r.columns)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	d := NewData()
+	for _, col := range r.columns {
+		d.AddColumn(*col)
+	}
+	d.mode = r.mode
+	cols := d.Columns()
+	for d.rows < size {
+		row, err := r.cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			r.record++
+			err = d.addRow(cols, r.positions, r.dialect, r.record, row)
+		}
+		if err != nil {
+			if d.rows == 0 {
+				return nil, err
+			}
+			r.err = err
+			break
+		}
+	}
+	if d.rows == 0 {
+		return nil, io.EOF
+	}
+	d.loaded = true
+	return d, nil
+}
@@ -0,0 +1,149 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"ml/constant"
+)
+
+// DefaultTargetFolds folds of TargetEncoder.FitTransform when Folds is 0
+const DefaultTargetFolds = 5
+
+// TargetEncoder encode string column by smoothed mean of label:
+// (count*mean + Smoothing*Prior) / (count + Smoothing), Prior is the mean
+// of all labels and is used for unknown categories
+type TargetEncoder struct {
+	Smoothing float64            `json:"smoothing"`
+	Folds     int                `json:"folds"`
+	Seed      int64              `json:"seed"`
+	Prior     float64            `json:"prior"`
+	Means     map[string]float64 `json:"means"`
+}
+
+type targetStats struct {
+	total float64
+	count int
+}
+
+// Fit fit smoothed means of category column c by int or float label
+// column, rows with missing category or label are skipped
+func (e *TargetEncoder) Fit(d *Data, c, label *Column) error {
+	stats, prior, err := e.stats(d, c, label, nil)
+	if err != nil {
+		return err
+	}
+	e.Prior = prior
+	e.Means = e.smooth(stats, prior)
+	return nil
+}
+
+// Transform encode string column to float column in place, missing
+// category is kept missing
+func (e *TargetEncoder) Transform(d *Data, c *Column) error {
+	if c.t != ColumnString {
+		return fmt.Errorf("encode %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	values := make([]float64, d.rows)
+	v := d.vector(c)
+	for i := range values {
+		if !v.isNull(i) {
+			values[i] = e.encode(e.Means, e.Prior, v.s[i])
+		}
+	}
+	e.replace(d, c, values)
+	return nil
+}
+
+// FitTransform fit by all rows for later Transform, then encode each row
+// of d by means fitted without its own fold to prevent label leakage
+func (e *TargetEncoder) FitTransform(d *Data, c, label *Column) error {
+	if err := e.Fit(d, c, label); err != nil {
+		return err
+	}
+	folds := e.Folds
+	if folds <= 0 {
+		folds = DefaultTargetFolds
+	}
+	r := rand.New(rand.NewSource(e.Seed))
+	fold := make([]int, d.rows)
+	for i := range fold {
+		fold[i] = r.Intn(folds)
+	}
+	v := d.vector(c)
+	values := make([]float64, d.rows)
+	for k := 0; k < folds; k++ {
+		stats, prior, err := e.stats(d, c, label, func(i int) bool {
+			return fold[i] != k
+		})
+		if errors.Is(err, constant.ErrNoValues) {
+			// no other rows to learn from, use the overall prior
+			stats, prior, err = nil, e.Prior, nil
+		}
+		if err != nil {
+			return err
+		}
+		means := e.smooth(stats, prior)
+		for i := range values {
+			if fold[i] == k && !v.isNull(i) {
+				values[i] = e.encode(means, prior, v.s[i])
+			}
+		}
+	}
+	e.replace(d, c, values)
+	return nil
+}
+
+func (e *TargetEncoder) stats(d *Data, c, label *Column, use func(int) bool) (map[string]*targetStats, float64, error) {
+	if c.t != ColumnString {
+		return nil, 0, fmt.Errorf("fit %s(%s): %w", c.name, c.t, constant.ErrValueType)
+	}
+	if label.t != ColumnInt && label.t != ColumnFloat {
+		return nil, 0, fmt.Errorf("label %s(%s): %w", label.name, label.t, constant.ErrValueType)
+	}
+	v, lv := d.vector(c), d.vector(label)
+	ret := make(map[string]*targetStats)
+	var total float64
+	var count int
+	for i := 0; i < d.rows; i++ {
+		if v.isNull(i) || lv.isNull(i) || (use != nil && !use(i)) {
+			continue
+		}
+		s := ret[v.s[i]]
+		if s == nil {
+			s = &targetStats{}
+			ret[v.s[i]] = s
+		}
+		s.total += lv.number(i)
+		s.count++
+		total += lv.number(i)
+		count++
+	}
+	if count == 0 {
+		return nil, 0, fmt.Errorf("fit %s: %w", c.name, constant.ErrNoValues)
+	}
+	return ret, total / float64(count), nil
+}
+
+func (e *TargetEncoder) smooth(stats map[string]*targetStats, prior float64) map[string]float64 {
+	ret := make(map[string]float64, len(stats))
+	for k, s := range stats {
+		ret[k] = (s.total + e.Smoothing*prior) / (float64(s.count) + e.Smoothing)
+	}
+	return ret
+}
+
+func (e *TargetEncoder) encode(means map[string]float64, prior float64, category string) float64 {
+	if n, ok := means[category]; ok {
+		return n
+	}
+	return prior
+}
+
+func (e *TargetEncoder) replace(d *Data, c *Column, values []float64) {
+	v := d.vector(c)
+	v.f = values
+	v.s = nil
+	v.t = ColumnFloat
+	c.t = ColumnFloat
+}
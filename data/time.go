@@ -0,0 +1,168 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// TimeFeature numeric feature extracted from time column
+type TimeFeature int
+
+const (
+	// TimeYear year, int
+	TimeYear TimeFeature = iota
+	// TimeMonth month of year 1-12, int
+	TimeMonth
+	// TimeQuarter quarter of year 1-4, int
+	TimeQuarter
+	// TimeWeekday day of week 0-6 start from Sunday, int
+	TimeWeekday
+	// TimeYearDay day of year 1-366, int
+	TimeYearDay
+	// TimeElapsedDays days since reference time, float
+	TimeElapsedDays
+	// TimeMonthSin sin of month cycle, float
+	TimeMonthSin
+	// TimeMonthCos cos of month cycle, float
+	TimeMonthCos
+	// TimeWeekdaySin sin of week cycle, float
+	TimeWeekdaySin
+	// TimeWeekdayCos cos of week cycle, float
+	TimeWeekdayCos
+	// TimeYearDaySin sin of year cycle, float
+	TimeYearDaySin
+	// TimeYearDayCos cos of year cycle, float
+	TimeYearDayCos
+)
+
+// AllTimeFeatures all time features
+var AllTimeFeatures = []TimeFeature{
+	TimeYear, TimeMonth, TimeQuarter, TimeWeekday, TimeYearDay, TimeElapsedDays,
+	TimeMonthSin, TimeMonthCos, TimeWeekdaySin, TimeWeekdayCos, TimeYearDaySin, TimeYearDayCos,
+}
+
+func (f TimeFeature) String() string {
+	switch f {
+	case TimeYear:
+		return "year"
+	case TimeMonth:
+		return "month"
+	case TimeQuarter:
+		return "quarter"
+	case TimeWeekday:
+		return "weekday"
+	case TimeYearDay:
+		return "yearday"
+	case TimeElapsedDays:
+		return "elapsed_days"
+	case TimeMonthSin:
+		return "month_sin"
+	case TimeMonthCos:
+		return "month_cos"
+	case TimeWeekdaySin:
+		return "weekday_sin"
+	case TimeWeekdayCos:
+		return "weekday_cos"
+	case TimeYearDaySin:
+		return "yearday_sin"
+	case TimeYearDayCos:
+		return "yearday_cos"
+	default:
+		return fmt.Sprintf("feature_%d", int(f))
+	}
+}
+
+func (f TimeFeature) columnType() ColumnType {
+	switch f {
+	case TimeYear, TimeMonth, TimeQuarter, TimeWeekday, TimeYearDay:
+		return ColumnInt
+	default:
+		return ColumnFloat
+	}
+}
+
+func (f TimeFeature) value(t, ref time.Time) float64 {
+	cycle := func(n, period float64) float64 {
+		return 2 * math.Pi * n / period
+	}
+	days := float64(time.Date(t.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay())
+	switch f {
+	case TimeYear:
+		return float64(t.Year())
+	case TimeMonth:
+		return float64(t.Month())
+	case TimeQuarter:
+		return float64((t.Month()-1)/3 + 1)
+	case TimeWeekday:
+		return float64(t.Weekday())
+	case TimeYearDay:
+		return float64(t.YearDay())
+	case TimeElapsedDays:
+		return t.Sub(ref).Hours() / 24
+	case TimeMonthSin:
+		return math.Sin(cycle(float64(t.Month()-1), 12))
+	case TimeMonthCos:
+		return math.Cos(cycle(float64(t.Month()-1), 12))
+	case TimeWeekdaySin:
+		return math.Sin(cycle(float64(t.Weekday()), 7))
+	case TimeWeekdayCos:
+		return math.Cos(cycle(float64(t.Weekday()), 7))
+	case TimeYearDaySin:
+		return math.Sin(cycle(float64(t.YearDay()-1), days))
+	case TimeYearDayCos:
+		return math.Cos(cycle(float64(t.YearDay()-1), days))
+	default:
+		return 0
+	}
+}
+
+// ExpandTime add numeric feature columns named <column>_time_<feature> from
+// time column, ref is the reference time of TimeElapsedDays, all features
+// are added when features is empty, missing time gets missing features
+func (d *Data) ExpandTime(c *Column, ref time.Time, features ...TimeFeature) []*Column {
+	if c.t != ColumnTime {
+		return nil
+	}
+	if len(features) == 0 {
+		features = AllTimeFeatures
+	}
+	src := d.vector(c)
+	ret := make([]*Column, len(features))
+	for j, f := range features {
+		t := f.columnType()
+		col := d.AddColumn(Column{
+			index: d.nextIndex(),
+			name:  c.name + "_time_" + f.String(),
+			t:     t,
+		})
+		v := newVector(t, d.rows)
+		for i := 0; i < d.rows; i++ {
+			if src.isNull(i) {
+				v.appendNull()
+				continue
+			}
+			n := f.value(src.ts[i], ref)
+			if t == ColumnInt {
+				v.appendInt(int64(n))
+			} else {
+				v.appendFloat(n)
+			}
+		}
+		d.vectors[col.index] = v
+		ret[j] = col
+	}
+	return ret
+}
+
+// GetTimeColumnNames get time feature column names ordered by index
+func (d *Data) GetTimeColumnNames(name string) []string {
+	ret := make([]string, 0, len(AllTimeFeatures))
+	for _, col := range d.Columns() {
+		if strings.HasPrefix(col.name, name+"_time_") {
+			ret = append(ret, col.name)
+		}
+	}
+	return ret
+}
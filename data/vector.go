@@ -0,0 +1,390 @@
+package data
+
+import (
+	"strconv"
+	"time"
+)
+
+// bitmap null bitmap, bit set means null
+type bitmap []uint64
+
+func (b bitmap) get(i int) bool {
+	w := i / 64
+	return w < len(b) && b[w]&(1<<uint(i%64)) != 0
+}
+
+func (b *bitmap) set(i int, null bool) {
+	w := i / 64
+	if !null {
+		if w < len(*b) {
+			(*b)[w] &^= 1 << uint(i%64)
+		}
+		return
+	}
+	for len(*b) <= w {
+		*b = append(*b, 0)
+	}
+	(*b)[w] |= 1 << uint(i%64)
+}
+
+func (b *bitmap) truncate(n int) {
+	w := (n + 63) / 64
+	if w < len(*b) {
+		*b = (*b)[:w]
+	}
+	if w > 0 && w <= len(*b) && n%64 != 0 {
+		(*b)[w-1] &= 1<<uint(n%64) - 1
+	}
+}
+
+// vector typed storage of one column, only the slice matching t is used
+type vector struct {
+	t    ColumnType
+	n    int
+	f    []float64
+	i    []int64
+	s    []string
+	ts   []time.Time
+	null bitmap
+}
+
+func newVector(t ColumnType, size int) *vector {
+	v := &vector{t: t}
+	switch t {
+	case ColumnFloat:
+		v.f = make([]float64, 0, size)
+	case ColumnInt:
+		v.i = make([]int64, 0, size)
+	case ColumnString:
+		v.s = make([]string, 0, size)
+	case ColumnTime:
+		v.ts = make([]time.Time, 0, size)
+	}
+	return v
+}
+
+func (v *vector) isNull(i int) bool {
+	return v.null.get(i)
+}
+
+func (v *vector) setNull(i int, null bool) {
+	v.null.set(i, null)
+}
+
+func (v *vector) nulls() int {
+	var ret int
+	for i := 0; i < v.n; i++ {
+		if v.null.get(i) {
+			ret++
+		}
+	}
+	return ret
+}
+
+// grow append one zero value
+func (v *vector) grow() {
+	switch v.t {
+	case ColumnFloat:
+		v.f = append(v.f, 0)
+	case ColumnInt:
+		v.i = append(v.i, 0)
+	case ColumnString:
+		v.s = append(v.s, "")
+	case ColumnTime:
+		v.ts = append(v.ts, time.Time{})
+	}
+	v.n++
+}
+
+func (v *vector) appendNull() {
+	v.grow()
+	v.null.set(v.n-1, true)
+}
+
+func (v *vector) appendFloat(n float64) {
+	v.f = append(v.f, n)
+	v.n++
+}
+
+func (v *vector) appendInt(n int64) {
+	v.i = append(v.i, n)
+	v.n++
+}
+
+func (v *vector) appendString(str string) {
+	v.s = append(v.s, str)
+	v.n++
+}
+
+func (v *vector) appendTime(t time.Time) {
+	v.ts = append(v.ts, t)
+	v.n++
+}
+
+// appendFrom append row i of src, src must have the same type
+func (v *vector) appendFrom(src *vector, i int) {
+	if src.isNull(i) {
+		v.appendNull()
+		return
+	}
+	switch v.t {
+	case ColumnFloat:
+		v.appendFloat(src.f[i])
+	case ColumnInt:
+		v.appendInt(src.i[i])
+	case ColumnString:
+		v.appendString(src.s[i])
+	case ColumnTime:
+		v.appendTime(src.ts[i])
+	}
+}
+
+// parse parse and append str, empty string means null
+func (v *vector) parse(str string, col *Column) error {
+	if len(str) == 0 {
+		v.appendNull()
+		return nil
+	}
+	switch v.t {
+	case ColumnString:
+		v.appendString(str)
+	case ColumnInt:
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.appendInt(n)
+	case ColumnFloat:
+		n, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return err
+		}
+		v.appendFloat(n)
+	case ColumnTime:
+		t, err := col.timeParse(str)
+		if err != nil {
+			return err
+		}
+		v.appendTime(t)
+	}
+	return nil
+}
+
+func (v *vector) truncate(n int) {
+	if n >= v.n {
+		return
+	}
+	switch v.t {
+	case ColumnFloat:
+		v.f = v.f[:n]
+	case ColumnInt:
+		v.i = v.i[:n]
+	case ColumnString:
+		v.s = v.s[:n]
+	case ColumnTime:
+		v.ts = v.ts[:n]
+	}
+	v.null.truncate(n)
+	v.n = n
+}
+
+// number get numeric value of row i, 0 for null or non-numeric column
+func (v *vector) number(i int) float64 {
+	if v.null.get(i) {
+		return 0
+	}
+	switch v.t {
+	case ColumnFloat:
+		return v.f[i]
+	case ColumnInt:
+		return float64(v.i[i])
+	default:
+		return 0
+	}
+}
+
+// cell get row i as cell
+func (v *vector) cell(i int, col *Column) *Cell {
+	cell := &Cell{t: v.t, empty: v.null.get(i)}
+	if cell.empty {
+		return cell
+	}
+	switch v.t {
+	case ColumnFloat:
+		cell.f = v.f[i]
+	case ColumnInt:
+		cell.i = int(v.i[i])
+	case ColumnString:
+		cell.s = v.s[i]
+	case ColumnTime:
+		cell.ts = v.ts[i]
+		cell.timeFormat = col.timeFormat
+	}
+	return cell
+}
+
+// setCell set row i by cell, cell is converted to type of vector
+func (v *vector) setCell(i int, cell *Cell) {
+	if cell.empty {
+		v.null.set(i, true)
+		return
+	}
+	switch v.t {
+	case ColumnFloat:
+		switch cell.t {
+		case ColumnFloat:
+			v.f[i] = cell.f
+		case ColumnInt:
+			v.f[i] = float64(cell.i)
+		default:
+			return
+		}
+	case ColumnInt:
+		switch cell.t {
+		case ColumnFloat:
+			v.i[i] = int64(cell.f)
+		case ColumnInt:
+			v.i[i] = int64(cell.i)
+		default:
+			return
+		}
+	case ColumnString:
+		if cell.t != ColumnString {
+			return
+		}
+		v.s[i] = cell.s
+	case ColumnTime:
+		if cell.t != ColumnTime {
+			return
+		}
+		v.ts[i] = cell.ts
+	}
+	v.null.set(i, false)
+}
+
+// toFloat convert int vector to float vector in place
+func (v *vector) toFloat() {
+	if v.t != ColumnInt {
+		return
+	}
+	v.f = make([]float64, v.n)
+	for i, n := range v.i {
+		v.f[i] = float64(n)
+	}
+	v.i = nil
+	v.t = ColumnFloat
+}
+
+// toInt convert string vector to int vector by fn in place
+func (v *vector) toInt(fn func(i int) int64) {
+	v.i = make([]int64, v.n)
+	for i := 0; i < v.n; i++ {
+		if v.null.get(i) {
+			continue
+		}
+		v.i[i] = fn(i)
+	}
+	v.s = nil
+	v.f = nil
+	v.ts = nil
+	v.t = ColumnInt
+}
+
+// clone deep copy of vector
+func (v *vector) clone() *vector {
+	ret := &vector{t: v.t, n: v.n}
+	ret.f = append([]float64(nil), v.f...)
+	ret.i = append([]int64(nil), v.i...)
+	ret.s = append([]string(nil), v.s...)
+	ret.ts = append([]time.Time(nil), v.ts...)
+	ret.null = append(bitmap(nil), v.null...)
+	return ret
+}
+
+// compare compare row i and row j, missing value is greater than any value
+func (v *vector) compare(i, j int) int {
+	ni, nj := v.isNull(i), v.isNull(j)
+	switch {
+	case ni && nj:
+		return 0
+	case ni:
+		return 1
+	case nj:
+		return -1
+	}
+	switch v.t {
+	case ColumnFloat:
+		return compareFloat(v.f[i], v.f[j])
+	case ColumnInt:
+		switch {
+		case v.i[i] < v.i[j]:
+			return -1
+		case v.i[i] > v.i[j]:
+			return 1
+		}
+	case ColumnString:
+		switch {
+		case v.s[i] < v.s[j]:
+			return -1
+		case v.s[i] > v.s[j]:
+			return 1
+		}
+	case ColumnTime:
+		switch {
+		case v.ts[i].Before(v.ts[j]):
+			return -1
+		case v.ts[i].After(v.ts[j]):
+			return 1
+		}
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// setFrom set row i by row j of src, src must have the same type
+func (v *vector) setFrom(i int, src *vector, j int) {
+	if src.isNull(j) {
+		v.null.set(i, true)
+		return
+	}
+	switch v.t {
+	case ColumnFloat:
+		v.f[i] = src.f[j]
+	case ColumnInt:
+		v.i[i] = src.i[j]
+	case ColumnString:
+		v.s[i] = src.s[j]
+	case ColumnTime:
+		v.ts[i] = src.ts[j]
+	}
+	v.null.set(i, false)
+}
+
+// nullVector create vector with n missing values
+func nullVector(t ColumnType, n int) *vector {
+	v := newVector(t, n)
+	for i := 0; i < n; i++ {
+		v.appendNull()
+	}
+	return v
+}
+
+// numbers get valid numeric values
+func (v *vector) numbers() []float64 {
+	ret := make([]float64, 0, v.n)
+	for i := 0; i < v.n; i++ {
+		if !v.isNull(i) {
+			ret = append(ret, v.number(i))
+		}
+	}
+	return ret
+}
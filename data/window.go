@@ -0,0 +1,143 @@
+package data
+
+import (
+	"fmt"
+	"ml/constant"
+	"sort"
+	"strconv"
+)
+
+// Window partition and order of window transforms, values are shifted by
+// position in partition, not by time distance
+type Window struct {
+	// PartitionBy partition columns, whole data when empty
+	PartitionBy []*Column
+	// OrderBy order column in partition, row order when nil
+	OrderBy *Column
+}
+
+// partitions get ordered row indexes of each partition
+func (d *Data) partitions(w Window) [][]int {
+	var groups [][]int
+	if len(w.PartitionBy) == 0 {
+		rows := make([]int, d.rows)
+		for i := range rows {
+			rows[i] = i
+		}
+		groups = [][]int{rows}
+	} else {
+		groups = d.GroupBy(w.PartitionBy...).Groups()
+	}
+	if w.OrderBy != nil {
+		v := d.vector(w.OrderBy)
+		for _, rows := range groups {
+			sort.SliceStable(rows, func(i, j int) bool {
+				return v.compare(rows[i], rows[j]) < 0
+			})
+		}
+	}
+	return groups
+}
+
+func (d *Data) addWindowColumn(c *Column, name string, t ColumnType, v *vector) *Column {
+	col := *c
+	col.index = d.nextIndex()
+	col.name = name
+	col.t = t
+	ret := d.AddColumn(col)
+	d.vectors[ret.index] = v
+	return ret
+}
+
+// Lag add column <column>_lag_<n> of value n rows before in partition,
+// missing when no such row, n must be positive
+func (d *Data) Lag(c *Column, n int, w Window) (*Column, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("lag %d of %s: %w", n, c.name, constant.ErrInvalidParam)
+	}
+	return d.shift(c, n, w, c.name+"_lag_"+strconv.Itoa(n)), nil
+}
+
+// Lead add column <column>_lead_<n> of value n rows after in partition,
+// missing when no such row, n must be positive
+func (d *Data) Lead(c *Column, n int, w Window) (*Column, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("lead %d of %s: %w", n, c.name, constant.ErrInvalidParam)
+	}
+	return d.shift(c, -n, w, c.name+"_lead_"+strconv.Itoa(n)), nil
+}
+
+func (d *Data) shift(c *Column, n int, w Window, name string) *Column {
+	src := d.vector(c)
+	v := nullVector(c.t, d.rows)
+	for _, rows := range d.partitions(w) {
+		for p, i := range rows {
+			if p-n < 0 || p-n >= len(rows) {
+				continue
+			}
+			v.setFrom(i, src, rows[p-n])
+		}
+	}
+	return d.addWindowColumn(c, name, c.t, v)
+}
+
+// PctChange add float column <column>_pct_change_<n> of change ratio
+// against value n rows before in partition, missing when no such row or
+// the previous value is missing or 0, n must be positive
+func (d *Data) PctChange(c *Column, n int, w Window) (*Column, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("pct_change %d of %s: %w", n, c.name, constant.ErrInvalidParam)
+	}
+	if c.t != ColumnInt && c.t != ColumnFloat {
+		return nil, fmt.Errorf("pct_change of %s(%s): %w", c.name, c.t, constant.ErrUnsupportedAggregation)
+	}
+	src := d.vector(c)
+	v := nullVector(ColumnFloat, d.rows)
+	for _, rows := range d.partitions(w) {
+		for p := n; p < len(rows); p++ {
+			i, j := rows[p], rows[p-n]
+			prev := src.number(j)
+			if src.isNull(i) || src.isNull(j) || prev == 0 {
+				continue
+			}
+			v.f[i] = src.number(i)/prev - 1
+			v.setNull(i, false)
+		}
+	}
+	return d.addWindowColumn(c, c.name+"_pct_change_"+strconv.Itoa(n), ColumnFloat, v), nil
+}
+
+// Rolling add column <column>_rolling_<fn>_<size> aggregated by fn over
+// the current row and size-1 rows before in partition, missing when the
+// window is incomplete or contains missing value
+func (d *Data) Rolling(c *Column, size int, fn AggFunc, w Window) (*Column, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("rolling %d of %s: %w", size, c.name, constant.ErrInvalidParam)
+	}
+	t, ok := fn.resultType(c.t)
+	if !ok {
+		return nil, fmt.Errorf("rolling %s of %s(%s): %w", fn, c.name, c.t, constant.ErrUnsupportedAggregation)
+	}
+	src := d.vector(c)
+	v := nullVector(t, d.rows)
+	tmp := newVector(t, 1)
+	for _, rows := range d.partitions(w) {
+		var nulls int
+		for p, i := range rows {
+			if src.isNull(i) {
+				nulls++
+			}
+			if p >= size && src.isNull(rows[p-size]) {
+				nulls--
+			}
+			if p < size-1 || nulls > 0 {
+				continue
+			}
+			tmp.truncate(0)
+			fn.apply(tmp, src, rows[p-size+1:p+1])
+			v.setFrom(i, tmp, 0)
+		}
+	}
+	name := fmt.Sprintf("%s_rolling_%s_%d", c.name, fn, size)
+	return d.addWindowColumn(c, name, t, v), nil
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// PrecisionShortest precision of the shortest float representation which
+// reads back the same value
+const PrecisionShortest = -1
+
+// WriteCSVOptions options of WriteCSV, zero value writes header, comma
+// delimited, empty missing values and floats without decimals
+type WriteCSVOptions struct {
+	// Delimiter field delimiter, default is comma
+	Delimiter rune
+	// Null token of missing values
+	Null string
+	// NoHeader skip the header row
+	NoHeader bool
+	// Precision digits after the decimal point of floats, shortest when
+	// PrecisionShortest or negative
+	Precision int
+	// TimeLayout layout of times, formatted by column when empty
+	TimeLayout string
+}
+
+// WriteCSV write data as csv row by row
+func (d *Data) WriteCSV(w io.Writer, opt WriteCSVOptions) error {
+	cw := csv.NewWriter(w)
+	if opt.Delimiter != 0 {
+		cw.Comma = opt.Delimiter
+	}
+	precision := opt.Precision
+	if precision < 0 {
+		precision = PrecisionShortest
+	}
+	cols := d.Columns()
+	row := make([]string, len(cols))
+	if !opt.NoHeader {
+		for idx, col := range cols {
+			row[idx] = col.name
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < d.rows; i++ {
+		for idx, col := range cols {
+			v := d.vectors[col.index]
+			if v.isNull(i) {
+				row[idx] = opt.Null
+				continue
+			}
+			switch v.t {
+			case ColumnFloat:
+				row[idx] = strconv.FormatFloat(v.f[i], 'f', precision, 64)
+			case ColumnInt:
+				row[idx] = strconv.FormatInt(v.i[i], 10)
+			case ColumnString:
+				row[idx] = v.s[i]
+			case ColumnTime:
+				row[idx] = formatTime(col, v.ts[i], opt.TimeLayout)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,79 @@
+package ml
+
+import (
+	"encoding/json"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func newCategoryData(t *testing.T, csv string) *data.Data {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("id", 0))
+	d.AddColumn(data.NewStringColumn("size", 1))
+	if err := d.LoadFromCSV(strings.NewReader(csv), false); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestLabelEncoder(t *testing.T) {
+	train := newCategoryData(t, "1,m\n2,s\n3,l\n4,\n5,m\n")
+	var e data.LabelEncoder
+	if err := e.FitTransform(train, train.GetColumnByName("size")); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(e.Categories, ",") != "l,m,s" || train.Row(1).Int("size") != 2 || !train.Row(3).IsNull("size") {
+		t.Fatalf("unexpected label encoding: %v", e.Categories)
+	}
+	ordinal := data.NewOrdinalEncoder([]string{"s", "m", "l"}, data.UnknownError)
+	test := newCategoryData(t, "1,l\n2,xl\n")
+	if err := ordinal.Transform(test, test.GetColumnByName("size")); err == nil {
+		t.Fatal("expect error for unknown category")
+	}
+	ordinal.Unknown = data.UnknownOther
+	if err := ordinal.Transform(test, test.GetColumnByName("size")); err != nil {
+		t.Fatal(err)
+	}
+	if test.Row(0).Int("size") != 2 || test.Row(1).Int("size") != 3 {
+		t.Fatal("unexpected ordinal encoding")
+	}
+	if err := ordinal.InverseTransform(test, test.GetColumnByName("size")); err != nil {
+		t.Fatal(err)
+	}
+	if test.Row(0).String("size") != "l" || test.Row(1).String("size") != data.OtherCategory {
+		t.Fatal("unexpected inverse transform")
+	}
+}
+
+func TestOneHotEncoder(t *testing.T) {
+	train := newCategoryData(t, "1,m\n2,s\n3,l\n")
+	e := data.OneHotEncoder{DropFirst: true, Unknown: data.UnknownIgnore}
+	cols, err := e.FitTransform(train, train.GetColumnByName("size"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := train.GetOneHotColumnNames("size")
+	if len(cols) != 2 || strings.Join(names, ",") != "size_onehot_m,size_onehot_s" {
+		t.Fatalf("unexpected columns: %v", names)
+	}
+	buf, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded data.OneHotEncoder
+	if err := json.Unmarshal(buf, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	test := newCategoryData(t, "1,s\n2,xl\n3,\n")
+	if _, err := loaded.Transform(test, test.GetColumnByName("size")); err != nil {
+		t.Fatal(err)
+	}
+	matrix := test.GetMatrix(2, 3)
+	if matrix[0][0] != 0 || matrix[0][1] != 1 || matrix[1][0] != 0 || matrix[1][1] != 0 {
+		t.Fatalf("unexpected matrix: %v", matrix)
+	}
+	if !test.Row(2).IsNull("size_onehot_s") {
+		t.Fatal("missing category should be missing")
+	}
+}
@@ -0,0 +1,93 @@
+package ml
+
+import (
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+const fillCSV = `2020-01-01,a,1,x
+2020-01-02,a,,
+2020-01-04,a,4,y
+2020-01-01,b,10,y
+2020-01-02,b,,
+2020-01-03,b,,y
+`
+
+func newFillData(t *testing.T) *data.Data {
+	d := data.NewData()
+	d.AddColumn(data.NewTimeLayoutColumn("date", 0, "2006-01-02"))
+	d.AddColumn(data.NewStringColumn("key", 1))
+	d.AddColumn(data.NewFloatColumn("value", 2))
+	d.AddColumn(data.NewStringColumn("tag", 3))
+	if err := d.LoadFromCSV(strings.NewReader(fillCSV), false); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func values(d *data.Data, name string) []interface{} {
+	ret := make([]interface{}, d.Total())
+	for i := range ret {
+		row := d.Row(i)
+		if row.IsNull(name) {
+			continue
+		}
+		if s := row.String(name); len(s) > 0 {
+			ret[i] = s
+		} else {
+			ret[i] = row.Float(name)
+		}
+	}
+	return ret
+}
+
+func expectValues(t *testing.T, d *data.Data, name string, want ...interface{}) {
+	got := values(d, name)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: expect %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	d := newFillData(t)
+	d.Fill(d.GetColumnByName("value"), data.Mean)
+	expectValues(t, d, "value", 1., 5., 4., 10., 5., 5.)
+	d = newFillData(t)
+	d.Fill(d.GetColumnByName("tag"), data.Mode)
+	expectValues(t, d, "tag", "x", "y", "y", "y", "y", "y")
+	d = newFillData(t)
+	d.Fill(d.GetColumnByName("value"), data.Median)
+	expectValues(t, d, "value", 1., 4., 4., 10., 4., 4.)
+	d = newFillData(t)
+	d.Fill(d.GetColumnByName("value"), data.Constant(-1))
+	expectValues(t, d, "value", 1., -1., 4., 10., -1., -1.)
+	d = newFillData(t)
+	d.Fill(d.GetColumnByName("value"), data.Max)
+	expectValues(t, d, "value", 1., 10., 4., 10., 10., 10.)
+	missing := data.NewData()
+	missing.AddColumn(data.NewIntColumn("id", 0))
+	missing.AddColumn(data.NewFloatColumn("value", 1))
+	if err := missing.LoadFromCSV(strings.NewReader("1,\n2,\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	missing.Fill(missing.GetColumnByName("value"), data.Max)
+	expectValues(t, missing, "value", nil, nil)
+
+	w := data.Window{PartitionBy: []*data.Column{d.GetColumnByName("key")}}
+	d = newFillData(t)
+	d.FillForward(d.GetColumnByName("value"), w)
+	expectValues(t, d, "value", 1., 1., 4., 10., 10., 10.)
+	d = newFillData(t)
+	d.FillBackward(d.GetColumnByName("value"), w)
+	expectValues(t, d, "value", 1., 4., 4., 10., nil, nil)
+	d = newFillData(t)
+	w.OrderBy = d.GetColumnByName("date")
+	d.FillInterpolate(d.GetColumnByName("value"), w)
+	expectValues(t, d, "value", 1., 2., 4., 10., nil, nil)
+	d = newFillData(t)
+	d.FillGroup(d.GetColumnByName("value"), data.Mean, d.GetColumnByName("key"))
+	expectValues(t, d, "value", 1., 2.5, 4., 10., 10., 10.)
+}
@@ -0,0 +1,48 @@
+package ml
+
+import (
+	"ml/data"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	d := loadLondon(t)
+	area := d.GetColumnByName("area")
+	price := d.GetColumnByName("average_price")
+	crimes := d.GetColumnByName("no_of_crimes")
+	ret, err := d.GroupBy(area).Agg(
+		data.Agg(price, data.AggCount),
+		data.Agg(price, data.AggMean),
+		data.Agg(price, data.AggMax),
+		data.Agg(crimes, data.AggCount),
+		data.Agg(d.GetColumnByName("date"), data.AggLast),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Total() != 45 {
+		t.Fatalf("unexpected groups: %d", ret.Total())
+	}
+	first := ret.Row(0)
+	if first.String("area") != "barking and dagenham" {
+		t.Fatalf("unexpected first group: %s", first.String("area"))
+	}
+	if first.Int("average_price_count") != 301 {
+		t.Fatalf("unexpected count: %d", first.Int("average_price_count"))
+	}
+	if ret.GetColumnByName("average_price_mean").GetType() != data.ColumnFloat ||
+		ret.GetColumnByName("average_price_max").GetType() != data.ColumnInt ||
+		ret.GetColumnByName("date_last").GetType() != data.ColumnTime {
+		t.Fatal("unexpected column types")
+	}
+	for i := 0; i < ret.Total(); i++ {
+		row := ret.Row(i)
+		if row.Float("average_price_mean") > row.Float("average_price_max") {
+			t.Fatalf("mean greater than max for %s", row.String("area"))
+		}
+	}
+	_, err = d.GroupBy(area).Agg(data.Agg(area, data.AggMean))
+	if err == nil {
+		t.Fatal("expect error for mean of string column")
+	}
+}
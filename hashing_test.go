@@ -0,0 +1,42 @@
+package ml
+
+import (
+	"ml/data"
+	"testing"
+)
+
+func TestHashingEncoder(t *testing.T) {
+	d := loadLondon(t)
+	e := data.HashingEncoder{Buckets: 8}
+	cols, err := e.Transform(d, d.GetColumnByName("code"), d.GetColumnByName("area"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 8 || cols[0].GetName() != "code_area_hash_0" {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+	index := make([]int, len(cols))
+	for i, col := range cols {
+		index[i] = col.GetIndex()
+	}
+	matrix := d.GetMatrix(index...)
+	for i, row := range matrix {
+		var nonzero int
+		for _, x := range row {
+			if x != 0 {
+				nonzero++
+			}
+		}
+		if nonzero != 1 {
+			t.Fatalf("unexpected hashed row %d: %v", i, row)
+		}
+	}
+	first := d.Row(0)
+	bucket, sign := e.Hash(first.String("code"), first.String("area"))
+	if matrix[0][bucket] != sign {
+		t.Fatal("hash not stable")
+	}
+	if _, err := (&data.HashingEncoder{}).Transform(d, cols[0]); err == nil {
+		t.Fatal("expect error for zero buckets")
+	}
+}
@@ -0,0 +1,73 @@
+package ml
+
+import (
+	"errors"
+	"ml/constant"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestCSVByName(t *testing.T) {
+	src := "extra,count,name\nx,1,a\ny,2,b\n"
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("name", 0))
+	d.AddColumn(data.NewIntColumn("count", 1))
+	if err := d.LoadFromCSVOptions(strings.NewReader(src), data.CSVOptions{ByName: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Columns()) != 2 || d.Total() != 2 {
+		t.Fatalf("unexpected data:\n%s", d.CSV())
+	}
+	if d.Row(1).String("name") != "b" || d.Row(1).Int("count") != 2 {
+		t.Fatalf("unexpected values:\n%s", d.CSV())
+	}
+
+	d = data.NewData()
+	d.AddColumn(data.NewFloatColumn("count", 0))
+	if err := d.LoadFromCSVOptions(strings.NewReader(src), data.CSVOptions{ByName: true, Infer: true}); err != nil {
+		t.Fatal(err)
+	}
+	if d.GetColumnByName("count").GetType() != data.ColumnFloat || d.Row(0).String("extra") != "x" || d.Row(0).String("name") != "a" {
+		t.Fatalf("unexpected inferred data:\n%s", d.CSV())
+	}
+
+	d = data.NewData()
+	d.AddColumn(data.NewStringColumn("missing", 0))
+	err := d.LoadFromCSVOptions(strings.NewReader(src), data.CSVOptions{ByName: true})
+	if !errors.Is(err, constant.ErrMissingColumn) || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expect missing column error, got %v", err)
+	}
+
+	d = data.NewData()
+	d.AddColumn(data.NewStringColumn("a", 0))
+	err = d.LoadFromCSVOptions(strings.NewReader("a,b,a\n1,2,3\n"), data.CSVOptions{ByName: true})
+	if !errors.Is(err, constant.ErrDuplicateColumn) {
+		t.Fatalf("expect duplicate column error, got %v", err)
+	}
+
+	r, err := data.NewCSVReaderOptions(strings.NewReader(src), data.CSVOptions{ByName: true},
+		data.NewStringColumn("name", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := r.Next()
+	if err != nil || row.String("name") != "a" {
+		t.Fatalf("unexpected stream row %v", err)
+	}
+}
+
+func TestCSVByNameThenJSON(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("k", 0))
+	d.AddColumn(data.NewIntColumn("v", 1))
+	if err := d.LoadFromCSVOptions(strings.NewReader("x,v,k\n0,1,a\n"), data.CSVOptions{ByName: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.LoadFromJSON(strings.NewReader(`[{"k":"b","v":2}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if d.Total() != 2 || d.Row(1).String("k") != "b" || d.Row(1).Int("v") != 2 {
+		t.Fatalf("unexpected data:\n%s", d.CSV())
+	}
+}
@@ -0,0 +1,66 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"ml/constant"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestFillKNN(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("x", 0))
+	d.AddColumn(data.NewFloatColumn("y", 1))
+	d.AddColumn(data.NewStringColumn("tag", 2))
+	csv := "1,10,a\n2,20,a\n3,,\n10,100,b\n11,110,b\n12,,\n"
+	if err := d.LoadFromCSV(strings.NewReader(csv), false); err != nil {
+		t.Fatal(err)
+	}
+	x := d.GetColumnByName("x")
+	if err := d.FillKNN(d.GetColumnByName("y"), 2, x); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FillKNN(d.GetColumnByName("tag"), 2, x); err != nil {
+		t.Fatal(err)
+	}
+	if d.Row(2).Float("y") != 15 || d.Row(5).Float("y") != 105 {
+		t.Fatalf("unexpected knn values: %f, %f", d.Row(2).Float("y"), d.Row(5).Float("y"))
+	}
+	if d.Row(2).String("tag") != "a" || d.Row(5).String("tag") != "b" {
+		t.Fatal("unexpected knn tags")
+	}
+	if err := d.FillKNN(x, 2, x); !errors.Is(err, constant.ErrInvalidParam) {
+		t.Fatalf("expect invalid param without features, got %v", err)
+	}
+}
+
+func TestFillIterative(t *testing.T) {
+	var buf strings.Builder
+	for i := 0; i < 100; i++ {
+		x := float64(i) / 10
+		if i%10 == 5 {
+			fmt.Fprintf(&buf, "%f,\n", x)
+			continue
+		}
+		fmt.Fprintf(&buf, "%f,%f\n", x, 2*x+1)
+	}
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("x", 0))
+	d.AddColumn(data.NewFloatColumn("y", 1))
+	if err := d.LoadFromCSV(strings.NewReader(buf.String()), false); err != nil {
+		t.Fatal(err)
+	}
+	cols := []*data.Column{d.GetColumnByName("x"), d.GetColumnByName("y")}
+	if _, err := d.FillIterative(cols, 10, 1e-3); err != nil {
+		t.Fatal(err)
+	}
+	for i := 5; i < 100; i += 10 {
+		row := d.Row(i)
+		if math.Abs(row.Float("y")-(2*row.Float("x")+1)) > 0.1 {
+			t.Fatalf("unexpected value at %d: %f", i, row.Float("y"))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package ml
+
+import (
+	"ml/data"
+	"os"
+	"testing"
+)
+
+func TestInferColumns(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("flag", 6))
+	f, err := os.Open("test_data/house_in_london.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = d.LoadFromCSVInfer(f, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]data.ColumnType{
+		"date":          data.ColumnTime,
+		"area":          data.ColumnString,
+		"average_price": data.ColumnInt,
+		"code":          data.ColumnString,
+		"houses_sold":   data.ColumnInt,
+		"no_of_crimes":  data.ColumnFloat,
+		"flag":          data.ColumnString,
+	}
+	for name, tp := range want {
+		col := d.GetColumnByName(name)
+		if col == nil {
+			t.Fatalf("column %s not found", name)
+		}
+		if col.GetType() != tp {
+			t.Fatalf("column %s: expect %s, got %s", name, tp, col.GetType())
+		}
+	}
+	if layout := d.GetColumnByName("date").GetTimeLayout(); layout != "2006-01-02" {
+		t.Fatalf("unexpected time layout: %s", layout)
+	}
+	if d.Total() != 13549 {
+		t.Fatalf("unexpected total: %d", d.Total())
+	}
+}
@@ -0,0 +1,53 @@
+package ml
+
+import (
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	d := loadLondon(t).Head(3)
+	boroughs := data.NewData()
+	boroughs.AddColumn(data.NewStringColumn("code", 0))
+	boroughs.AddColumn(data.NewStringColumn("area", 1))
+	boroughs.AddColumn(data.NewIntColumn("population", 2))
+	err := boroughs.LoadFromCSV(strings.NewReader(
+		"E09000001,City of London,8706\nE09000002,Barking and Dagenham,\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := data.JoinOptions{How: data.JoinInner, On: []string{"code"}}
+	inner, err := d.Join(boroughs, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.Total() != 3 {
+		t.Fatalf("unexpected inner rows: %d", inner.Total())
+	}
+	for _, name := range []string{"code", "area_left", "area_right", "population", "date"} {
+		if inner.GetColumnByName(name) == nil {
+			t.Fatalf("column %s not found", name)
+		}
+	}
+	if inner.Row(0).Int("population") != 8706 ||
+		inner.GetColumnByName("date").GetType() != data.ColumnTime {
+		t.Fatal("unexpected inner join result")
+	}
+	opt.How = data.JoinOuter
+	outer, err := d.Join(boroughs, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outer.Total() != 4 {
+		t.Fatalf("unexpected outer rows: %d", outer.Total())
+	}
+	last := outer.Row(3)
+	if last.String("code") != "E09000002" || !last.IsNull("date") || !last.IsNull("population") {
+		t.Fatal("unexpected outer join result")
+	}
+	opt.On = []string{"population"}
+	if _, err := d.Join(boroughs, opt); err == nil {
+		t.Fatal("expect error for missing key")
+	}
+}
@@ -0,0 +1,93 @@
+package ml
+
+import (
+	"bytes"
+	"math"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	d := loadLondon(t).Head(20)
+	for _, jsonl := range []bool{false, true} {
+		var buf bytes.Buffer
+		var err error
+		if jsonl {
+			err = d.WriteJSONL(&buf, "2006-01-02")
+		} else {
+			err = d.WriteJSON(&buf, "2006-01-02")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), `"no_of_crimes":null`) {
+			t.Fatal("missing value not written as null")
+		}
+		loaded := data.NewData()
+		if jsonl {
+			err = loaded.LoadFromJSONL(&buf)
+		} else {
+			err = loaded.LoadFromJSON(&buf)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.GetColumnByName("date").GetType() != data.ColumnTime ||
+			loaded.GetColumnByName("average_price").GetType() != data.ColumnInt {
+			t.Fatal("unexpected inferred columns")
+		}
+		if loaded.CSV() != d.CSV() {
+			t.Fatalf("json round trip failed:\n%s\n%s", loaded.CSV(), d.CSV())
+		}
+	}
+}
+
+func TestJSONDeclaredColumns(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("price", 0))
+	d.AddColumn(data.NewIntColumn("flag", 1))
+	err := d.LoadFromJSON(strings.NewReader(
+		`[{"flag": true, "price": 1.5, "other": [1]}, {"price": null, "flag": false}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Total() != 2 || d.GetColumnByName("other") != nil {
+		t.Fatal("unexpected columns")
+	}
+	if d.Row(0).Float("price") != 1.5 || d.Row(0).Int("flag") != 1 || !d.Row(1).IsNull("price") {
+		t.Fatal("unexpected values")
+	}
+	err = data.NewData().LoadFromJSONL(strings.NewReader("{\"a\": 1}\n{\"a\": \"x\"}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteJSONNonFinite(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("x", 0))
+	if err := d.LoadFromCSV(strings.NewReader("1\n2\n3\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	values := []float64{math.Inf(1), math.NaN(), 1.5}
+	_, err := d.AddComputedColumn("y", data.ColumnFloat, func(row data.Row) interface{} {
+		return values[row.Index()]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.AddX0()
+	d.Normalize(d.GetColumnByName("x0"), data.Constant(0.))
+	var buf bytes.Buffer
+	if err := d.WriteJSONL(&buf, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"x0":null,"x":1,"y":null}
+{"x0":null,"x":2,"y":null}
+{"x0":null,"x":3,"y":1.5}
+`
+	if buf.String() != want {
+		t.Fatalf("unexpected json:\n%s", buf.String())
+	}
+}
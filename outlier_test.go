@@ -0,0 +1,53 @@
+package ml
+
+import (
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func newOutlierData(t *testing.T) *data.Data {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("x", 0))
+	d.AddColumn(data.NewFloatColumn("y", 1))
+	csv := "1,1\n2,2\n3,3\n4,4\n5,\n6,6\n7,7\n8,8\n9,9\n100,1000\n"
+	if err := d.LoadFromCSV(strings.NewReader(csv), false); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestOutliers(t *testing.T) {
+	d := newOutlierData(t)
+	for _, method := range []data.OutlierMethod{data.OutlierIQR, data.OutlierMAD} {
+		o, err := d.DetectOutliers(d.GetColumnByName("y"), method, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(o.Rows) != 1 || o.Rows[0] != 9 {
+			t.Fatalf("method %d: unexpected outliers %v", method, o.Rows)
+		}
+	}
+	o, err := d.DetectOutliers(d.GetColumnByName("x"), data.OutlierZScore, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Rows) != 1 {
+		t.Fatalf("unexpected z-score outliers %v", o.Rows)
+	}
+	if dropped := d.DropRows(o.Rows); dropped.Total() != 9 || d.Total() != 10 {
+		t.Fatal("unexpected drop rows")
+	}
+	if err := d.Clip(d.GetColumnByName("x"), o.Lower, o.Upper); err != nil {
+		t.Fatal(err)
+	}
+	if d.Row(9).Int("x") != int64(o.Upper) {
+		t.Fatalf("unexpected clipped value: %d", d.Row(9).Int("x"))
+	}
+	if err := d.Winsorize(d.GetColumnByName("y"), 0, .5); err != nil {
+		t.Fatal(err)
+	}
+	if d.Row(9).Float("y") != 6 || d.Row(0).Float("y") != 1 || !d.Row(4).IsNull("y") {
+		t.Fatal("unexpected winsorized values")
+	}
+}
@@ -0,0 +1,103 @@
+package ml
+
+import (
+	"errors"
+	"ml/constant"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+const badCSV = `id,price,date
+1,10.5,2020-01-01
+2,12a,2020-02-01
+3,7,2020-13-01
+`
+
+func newBadData() *data.Data {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("id", 0))
+	d.AddColumn(data.NewFloatColumn("price", 1))
+	d.AddColumn(data.NewTimeLayoutColumn("date", 2, "2006-01-02"))
+	return d
+}
+
+func TestParseStrict(t *testing.T) {
+	d := newBadData()
+	err := d.LoadFromCSV(strings.NewReader(badCSV), true)
+	e, ok := err.(*data.CellError)
+	if !ok {
+		t.Fatalf("expect *data.CellError, got %v", err)
+	}
+	if e.Record != 3 || e.Column != "price" || e.Value != "12a" {
+		t.Fatalf("unexpected error: %v", e)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	d := newBadData()
+	d.SetParseMode(data.ParseLenient)
+	err := d.LoadFromCSV(strings.NewReader(badCSV), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := d.ParseErrors()
+	if len(errs) != 2 {
+		t.Fatalf("expect 2 errors, got %d", len(errs))
+	}
+	if errs[0].Record != 3 || errs[0].Column != "price" {
+		t.Fatalf("unexpected error: %v", errs[0])
+	}
+	if errs[1].Record != 4 || errs[1].Column != "date" || errs[1].Value != "2020-13-01" {
+		t.Fatalf("unexpected error: %v", errs[1])
+	}
+	if d.Total() != 3 {
+		t.Fatalf("unexpected total: %d", d.Total())
+	}
+	err = d.LoadFromCSV(strings.NewReader("4,x,2020-03-01\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.ParseErrors()) != 1 || d.ParseErrors()[0].Record != 1 {
+		t.Fatalf("errors of previous loading not cleared: %v", d.ParseErrors())
+	}
+}
+
+func TestParseMissingField(t *testing.T) {
+	d := newBadData()
+	d.AddColumn(data.NewStringColumn("extra", 3))
+	err := d.LoadFromCSV(strings.NewReader(badCSV), true)
+	var e *data.CellError
+	if !errors.As(err, &e) || !errors.Is(err, constant.ErrMissingField) || e.Column != "extra" {
+		t.Fatalf("expect missing field error, got %v", err)
+	}
+	if d.Total() != 0 {
+		t.Fatalf("unexpected total: %d", d.Total())
+	}
+}
+
+func TestParseLenientRaggedRecord(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("a", 0))
+	d.AddColumn(data.NewIntColumn("b", 1))
+	d.SetParseMode(data.ParseLenient)
+	if err := d.LoadFromCSV(strings.NewReader("1,2\n3\n4,5\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	if d.Total() != 3 || d.Row(1).Int("a") != 3 || !d.Row(1).IsNull("b") || d.Row(2).Int("b") != 5 {
+		t.Fatalf("unexpected data:\n%s", d.CSV())
+	}
+	errs := d.ParseErrors()
+	if len(errs) != 1 || errs[0].Record != 2 || errs[0].Column != "b" || !errors.Is(errs[0], constant.ErrMissingField) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	d = data.NewData()
+	d.SetParseMode(data.ParseLenient)
+	if err := d.LoadFromCSVInfer(strings.NewReader("a,b\n1,2\n3\n4,5\n"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if d.GetColumnByName("b").GetType() != data.ColumnInt || len(d.ParseErrors()) != 1 || d.ParseErrors()[0].Record != 3 {
+		t.Fatalf("unexpected inferred data:\n%s", d.CSV())
+	}
+}
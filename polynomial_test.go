@@ -0,0 +1,44 @@
+package ml
+
+import (
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestPolynomial(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("a", 0))
+	d.AddColumn(data.NewFloatColumn("b", 1))
+	if err := d.LoadFromCSV(strings.NewReader("2,3\n4,\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	cols := []*data.Column{d.GetColumnByName("a"), d.GetColumnByName("b")}
+	terms, err := d.AddPolynomial(cols, 3, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(terms))
+	for i, col := range terms {
+		names[i] = col.GetName()
+	}
+	if strings.Join(names, ",") != "a^2,a*b,b^2,a^3,a^2*b,a*b^2,b^3" {
+		t.Fatalf("unexpected terms: %v", names)
+	}
+	row := d.Row(0)
+	if row.Float("a^2*b") != 12 || row.Float("b^3") != 27 {
+		t.Fatal("unexpected term values")
+	}
+	if row = d.Row(1); row.Float("a^3") != 64 || !row.IsNull("a*b") {
+		t.Fatal("unexpected missing terms")
+	}
+	d.AddColumn(data.NewFloatColumn("c", d.Columns()[len(d.Columns())-1].GetIndex()+1))
+	cols = append(cols, d.GetColumnByName("c"))
+	terms, err = d.AddPolynomial(cols, 3, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(terms) != 4 || terms[3].GetName() != "a*b*c" {
+		t.Fatalf("unexpected interaction terms: %d", len(terms))
+	}
+}
@@ -0,0 +1,44 @@
+package ml
+
+import (
+	"math"
+	"ml/data"
+	"testing"
+)
+
+func TestPowerTransform(t *testing.T) {
+	transformers := []data.Transformer{
+		&data.Log1pTransformer{},
+		&data.SqrtTransformer{},
+		&data.BoxCoxTransformer{},
+		&data.YeoJohnsonTransformer{},
+	}
+	for _, tr := range transformers {
+		d := loadLondon(t)
+		price := d.GetColumnByName("average_price")
+		prices := d.GetLables(price)
+		skew := d.Stats(price).Skew
+		if err := d.FitTransform(price, tr); err != nil {
+			t.Fatal(err)
+		}
+		if s := d.Stats(price); math.Abs(s.Skew) >= math.Abs(skew) || s.Missing != 0 {
+			t.Fatalf("%T: skew not reduced: %f -> %f", tr, skew, s.Skew)
+		}
+		transformed := d.GetLables(price)
+		for i, p := range prices {
+			if math.Abs(tr.Inverse(transformed[i])-p)/p > 1e-6 {
+				t.Fatalf("%T: inverse failed at %d: %f != %f", tr, i, tr.Inverse(transformed[i]), p)
+			}
+		}
+	}
+	boxCox := &data.BoxCoxTransformer{Lambda: 0}
+	if math.Abs(boxCox.Transform(math.E)-1) > 1e-9 {
+		t.Fatal("box-cox with lambda 0 should be log")
+	}
+	yeoJohnson := &data.YeoJohnsonTransformer{Lambda: .5}
+	for _, x := range []float64{-3, -.5, 0, 2} {
+		if math.Abs(yeoJohnson.Inverse(yeoJohnson.Transform(x))-x) > 1e-9 {
+			t.Fatalf("yeo-johnson inverse failed for %f", x)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ml
+
+import (
+	"encoding/json"
+	"math"
+	"ml/data"
+	"reflect"
+	"testing"
+)
+
+func TestScaler(t *testing.T) {
+	scalers := []data.Transformer{
+		&data.MinMaxScaler{},
+		&data.StandardScaler{},
+		&data.MaxAbsScaler{},
+		&data.RobustScaler{},
+	}
+	for _, s := range scalers {
+		d := loadLondon(t)
+		train := d.Head(1000)
+		test := d.Tail(1000)
+		price := test.GetColumnByName("average_price")
+		prices := test.GetLables(price)
+		if err := s.Fit(train, train.GetColumnByName("average_price")); err != nil {
+			t.Fatal(err)
+		}
+		buf, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded := reflect.New(reflect.TypeOf(s).Elem()).Interface().(data.Transformer)
+		if err := json.Unmarshal(buf, loaded); err != nil {
+			t.Fatal(err)
+		}
+		if err := test.Transform(price, loaded); err != nil {
+			t.Fatal(err)
+		}
+		if price.GetType() != data.ColumnFloat {
+			t.Fatalf("%T: column not converted to float", s)
+		}
+		scaled := test.GetLables(price)
+		for i, p := range prices {
+			if math.Abs(scaled[i]-s.Transform(p)) > 1e-9 || math.Abs(loaded.Inverse(scaled[i])-p) > 1e-6 {
+				t.Fatalf("%T: unexpected value at %d", s, i)
+			}
+		}
+		if err := test.InverseTransform(price, loaded); err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(test.Row(0).Float("average_price")-prices[0]) > 1e-6 {
+			t.Fatalf("%T: inverse transform failed", s)
+		}
+	}
+	minMax := &data.MinMaxScaler{Min: 10, Max: 20}
+	if minMax.Transform(15) != .5 || minMax.Inverse(.5) != 15 {
+		t.Fatal("unexpected min max scale")
+	}
+}
@@ -0,0 +1,61 @@
+package ml
+
+import (
+	"ml/data"
+	"os"
+	"testing"
+	"time"
+)
+
+func loadLondon(t testing.TB) *data.Data {
+	d := data.NewData()
+	for _, col := range londonColumns() {
+		d.AddColumn(col)
+	}
+	f, err := os.Open("test_data/house_in_london.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = d.LoadFromCSV(f, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestFilterAndSort(t *testing.T) {
+	d := loadLondon(t)
+	since := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	hackney := d.Filter(func(row data.Row) bool {
+		return row.String("area") == "hackney" && !row.Time("date").Before(since)
+	})
+	if hackney.Total() != 121 {
+		t.Fatalf("unexpected total: %d", hackney.Total())
+	}
+	if d.Total() != 13549 {
+		t.Fatal("original data changed")
+	}
+	sorted := hackney.SortBy(data.Desc(hackney.GetColumnByName("average_price")))
+	prices := sorted.GetLables(sorted.GetColumnByName("average_price"))
+	for i := 1; i < len(prices); i++ {
+		if prices[i] > prices[i-1] {
+			t.Fatalf("not sorted at %d", i)
+		}
+	}
+	head := sorted.Head(3)
+	tail := sorted.Tail(3)
+	if head.Total() != 3 || tail.Total() != 3 {
+		t.Fatalf("unexpected head=%d, tail=%d", head.Total(), tail.Total())
+	}
+	if head.Row(0).Int("average_price") != int64(prices[0]) ||
+		tail.Row(2).Int("average_price") != int64(prices[len(prices)-1]) {
+		t.Fatal("unexpected head or tail")
+	}
+	complete := d.Filter(func(row data.Row) bool {
+		return !row.HasNull()
+	})
+	if complete.Stats(complete.GetColumnByName("no_of_crimes")).Missing != 0 {
+		t.Fatal("missing values not dropped")
+	}
+}
@@ -0,0 +1,35 @@
+package ml
+
+import (
+	"math"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestColumnStats(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("n", 0))
+	d.AddColumn(data.NewStringColumn("s", 1))
+	err := d.LoadFromCSV(strings.NewReader("1,a\n2,b\n3,a\n4,\n,c\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := d.Stats(d.GetColumnByName("n"))
+	if s.Count != 4 || s.Missing != 1 {
+		t.Fatalf("unexpected count=%d, missing=%d", s.Count, s.Missing)
+	}
+	if s.Mean != 2.5 || s.Min != 1 || s.Max != 4 {
+		t.Fatalf("unexpected mean=%f, min=%f, max=%f", s.Mean, s.Min, s.Max)
+	}
+	if math.Abs(s.Variance-5./3) > 1e-9 || math.Abs(s.Std-math.Sqrt(5./3)) > 1e-9 {
+		t.Fatalf("unexpected variance=%f, std=%f", s.Variance, s.Std)
+	}
+	if s.Quantile(.25) != 1.75 || s.Quantile(.5) != 2.5 {
+		t.Fatalf("unexpected quantiles: %v", s.Quantiles)
+	}
+	s = d.Stats(d.GetColumnByName("s"))
+	if s.Unique != 3 || s.Top[0].Value != "a" || s.Top[0].Count != 2 {
+		t.Fatalf("unexpected top: %v", s.Top)
+	}
+}
@@ -0,0 +1,91 @@
+package ml
+
+import (
+	"io"
+	"ml/data"
+	"os"
+	"strings"
+	"testing"
+)
+
+func londonColumns() []data.Column {
+	return []data.Column{
+		data.NewTimeLayoutColumn("date", 0, "2006-01-02"),
+		data.NewStringColumn("area", 1),
+		data.NewIntColumn("average_price", 2),
+		data.NewStringColumn("code", 3),
+		data.NewIntColumn("houses_sold", 4),
+		data.NewFloatColumn("no_of_crimes", 5),
+		data.NewIntColumn("borough_flag", 6),
+	}
+}
+
+func TestStreamCSV(t *testing.T) {
+	f, err := os.Open("test_data/house_in_london.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r := data.NewCSVReader(f, true, londonColumns()...)
+	var rows, nulls int
+	var total float64
+	for {
+		row, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows++
+		total += row.Float("average_price")
+		if row.IsNull("no_of_crimes") {
+			nulls++
+		}
+	}
+	if rows != 13549 {
+		t.Fatalf("unexpected rows: %d", rows)
+	}
+	if nulls == 0 || total <= 0 {
+		t.Fatalf("unexpected nulls=%d, total=%f", nulls, total)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	r = data.NewCSVReader(f, true, londonColumns()...)
+	var chunks, chunkRows int
+	for {
+		chunk, err := r.NextChunk(1000)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks++
+		chunkRows += chunk.Total()
+	}
+	if chunks != 14 || chunkRows != rows {
+		t.Fatalf("unexpected chunks=%d, rows=%d", chunks, chunkRows)
+	}
+}
+
+func TestStreamBadRecord(t *testing.T) {
+	r := data.NewCSVReader(strings.NewReader("a,1\nb,x\nc,3\n"), false,
+		data.NewStringColumn("k", 0), data.NewIntColumn("v", 1))
+	row, err := r.Next()
+	if err != nil || row.String("k") != "a" {
+		t.Fatalf("rows before bad record should be returned, got %v", err)
+	}
+	if _, err := r.Next(); err == nil {
+		t.Fatal("expect error of bad record")
+	}
+	row, err = r.Next()
+	if err != nil || row.String("k") != "c" || row.Int("v") != 3 {
+		t.Fatalf("unexpected row after bad record, got %v", err)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("expect EOF, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package ml
+
+import (
+	"math"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestTargetEncoder(t *testing.T) {
+	d := loadLondon(t)
+	area := d.GetColumnByName("area")
+	price := d.GetColumnByName("average_price")
+	e := data.TargetEncoder{Smoothing: 10, Folds: 5, Seed: 1}
+	if err := e.FitTransform(d, area, price); err != nil {
+		t.Fatal(err)
+	}
+	if area.GetType() != data.ColumnFloat || len(e.Means) != 45 {
+		t.Fatalf("unexpected encoding: %s, %d", area.GetType(), len(e.Means))
+	}
+	// out of fold values differ from the full fit
+	if d.Row(0).Float("area") == e.Means["city of london"] {
+		t.Fatal("expect out of fold value")
+	}
+	if math.Abs(d.Row(0).Float("area")-e.Means["city of london"])/e.Means["city of london"] > .1 {
+		t.Fatal("out of fold value too far from full fit")
+	}
+	test := data.NewData()
+	test.AddColumn(data.NewStringColumn("area", 0))
+	if err := test.LoadFromCSV(strings.NewReader("city of london\nnowhere\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Transform(test, test.GetColumnByName("area")); err != nil {
+		t.Fatal(err)
+	}
+	if test.Row(0).Float("area") != e.Means["city of london"] || test.Row(1).Float("area") != e.Prior {
+		t.Fatal("unexpected transform")
+	}
+}
+
+func TestTargetEncoderSingleFold(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewStringColumn("k", 0))
+	d.AddColumn(data.NewFloatColumn("y", 1))
+	if err := d.LoadFromCSV(strings.NewReader("a,1\nb,3\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	e := &data.TargetEncoder{}
+	if err := e.FitTransform(d, d.GetColumnByName("k"), d.GetColumnByName("y")); err != nil {
+		t.Fatal(err)
+	}
+	// both rows fall in one fold, which has no other rows to learn from
+	if d.Row(0).Float("k") != e.Prior || d.Row(1).Float("k") != e.Prior {
+		t.Fatalf("expect prior %f:\n%s", e.Prior, d.CSV())
+	}
+}
@@ -0,0 +1,34 @@
+package ml
+
+import (
+	"math"
+	"ml/data"
+	"testing"
+	"time"
+)
+
+func TestExpandTime(t *testing.T) {
+	d := loadLondon(t).Head(13)
+	ref := time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC)
+	cols := d.ExpandTime(d.GetColumnByName("date"), ref,
+		data.TimeYear, data.TimeQuarter, data.TimeElapsedDays, data.TimeMonthSin)
+	if len(cols) != 4 {
+		t.Fatalf("unexpected columns: %d", len(cols))
+	}
+	names := d.GetTimeColumnNames("date")
+	if len(names) != 4 || names[0] != "date_time_year" || names[3] != "date_time_month_sin" {
+		t.Fatalf("unexpected names: %v", names)
+	}
+	row := d.Row(12) // 1996-01-01
+	if row.Int("date_time_year") != 1996 || row.Int("date_time_quarter") != 1 ||
+		row.Float("date_time_elapsed_days") != 365 {
+		t.Fatal("unexpected time features")
+	}
+	if math.Abs(d.Row(3).Float("date_time_month_sin")-1) > 1e-9 {
+		t.Fatalf("unexpected month sin: %f", d.Row(3).Float("date_time_month_sin"))
+	}
+	matrix := d.GetMatrix(cols[0].GetIndex())
+	if matrix[0][0] != 1995 {
+		t.Fatalf("unexpected matrix value: %f", matrix[0][0])
+	}
+}
@@ -0,0 +1,97 @@
+package ml
+
+import (
+	"fmt"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestNullBitmap(t *testing.T) {
+	var src strings.Builder
+	for i := 0; i < 130; i++ {
+		if i%3 == 0 {
+			src.WriteString(",\n")
+		} else {
+			fmt.Fprintf(&src, "%d,%d\n", i, i)
+		}
+	}
+	d := data.NewData()
+	d.AddColumn(data.NewIntColumn("a", 0))
+	d.AddColumn(data.NewFloatColumn("b", 1))
+	if err := d.LoadFromCSV(strings.NewReader(src.String()), false); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < d.Total(); i++ {
+		row := d.Row(i)
+		if row.IsNull("a") != (i%3 == 0) || row.IsNull("b") != (i%3 == 0) {
+			t.Fatalf("unexpected null at %d", i)
+		}
+		if i%3 != 0 && (row.Int("a") != int64(i) || row.Float("b") != float64(i)) {
+			t.Fatalf("unexpected value at %d", i)
+		}
+	}
+}
+
+func TestNullBitmapTruncate(t *testing.T) {
+	for _, n := range []int{63, 64, 65, 70, 128} {
+		// the bad record sets a null bit of a before b fails and is truncated
+		src := strings.Repeat("1,1\n", n) + ",x\n"
+		d := data.NewData()
+		d.AddColumn(data.NewIntColumn("a", 0))
+		d.AddColumn(data.NewIntColumn("b", 1))
+		if err := d.LoadFromCSV(strings.NewReader(src), false); err == nil {
+			t.Fatalf("%d: expect error of bad record", n)
+		}
+		if d.Total() != n {
+			t.Fatalf("%d: unexpected total %d", n, d.Total())
+		}
+		if err := d.LoadFromCSV(strings.NewReader("2,2\n,3\n"), false); err != nil {
+			t.Fatal(err)
+		}
+		if d.Row(n).IsNull("a") || d.Row(n).Int("a") != 2 || !d.Row(n+1).IsNull("a") {
+			t.Fatalf("%d: stale null bit after truncate", n)
+		}
+		if d.Row(n - 1).IsNull("a") {
+			t.Fatalf("%d: null bit lost before truncate", n)
+		}
+	}
+}
+
+func TestGetMatrixOrder(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("c", 7))
+	d.AddColumn(data.NewIntColumn("a", 0))
+	d.AddColumn(data.NewStringColumn("s", 5))
+	d.AddColumn(data.NewFloatColumn("b", 3))
+	if err := d.LoadFromCSV(strings.NewReader("1,,,2,,x,,3\n4,,,,,y,,6\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	expect := [][]float64{{1, 2, 0, 3}, {4, 0, 0, 6}}
+	if got := d.GetMatrix(); fmt.Sprint(got) != fmt.Sprint(expect) {
+		t.Fatalf("unexpected matrix %v", got)
+	}
+	if got := d.GetMatrix(7, 0); fmt.Sprint(got) != fmt.Sprint([][]float64{{3, 1}, {6, 4}}) {
+		t.Fatalf("unexpected matrix of columns %v", got)
+	}
+	d.AddX0()
+	expect = [][]float64{{1, 1, 2, 0, 3}, {1, 4, 0, 0, 6}}
+	if got := d.GetMatrix(); fmt.Sprint(got) != fmt.Sprint(expect) {
+		t.Fatalf("unexpected matrix with x0 %v", got)
+	}
+	if d.GetColumnByName("c").GetIndex() != 8 || d.GetColumnByIndex(4) != d.GetColumnByName("b") {
+		t.Fatal("unexpected indexes after AddX0")
+	}
+	if d.Row(1).Float("c") != 6 || !d.Row(1).IsNull("b") {
+		t.Fatal("vectors not moved with columns")
+	}
+}
+
+func BenchmarkGetMatrix(b *testing.B) {
+	d := loadLondon(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.GetMatrix()
+	}
+}
@@ -0,0 +1,94 @@
+package ml
+
+import (
+	"errors"
+	"math"
+	"ml/constant"
+	"ml/data"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	d := loadLondon(t)
+	d = d.SortBy(data.Desc(d.GetColumnByName("date")))
+	price := d.GetColumnByName("average_price")
+	w := data.Window{
+		PartitionBy: []*data.Column{d.GetColumnByName("area")},
+		OrderBy:     d.GetColumnByName("date"),
+	}
+	lag, err := d.Lag(price, 1, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lead, err := d.Lead(price, 1, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mean, err := d.Rolling(price, 12, data.AggMean, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yoy, err := d.PctChange(price, 12, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lag.GetName() != "average_price_lag_1" || mean.GetName() != "average_price_rolling_mean_12" {
+		t.Fatalf("unexpected names: %s, %s", lag.GetName(), mean.GetName())
+	}
+	hackney := d.Filter(func(row data.Row) bool {
+		return row.String("area") == "hackney"
+	}).SortBy(data.Asc(d.GetColumnByName("date")))
+	prices := hackney.GetLables(hackney.GetColumnByName("average_price"))
+	for i := 0; i < hackney.Total(); i++ {
+		row := hackney.Row(i)
+		if i == 0 {
+			if !row.IsNull(lag.GetName()) || !row.IsNull(yoy.GetName()) {
+				t.Fatal("first row of partition should be missing")
+			}
+		} else if row.Float(lag.GetName()) != prices[i-1] {
+			t.Fatalf("unexpected lag at %d", i)
+		}
+		if i == hackney.Total()-1 && !row.IsNull(lead.GetName()) {
+			t.Fatal("last row of partition should be missing")
+		}
+		if i < 11 {
+			if !row.IsNull(mean.GetName()) {
+				t.Fatalf("incomplete window should be missing at %d", i)
+			}
+			continue
+		}
+		var total float64
+		for _, p := range prices[i-11 : i+1] {
+			total += p
+		}
+		if math.Abs(row.Float(mean.GetName())-total/12) > 1e-6 {
+			t.Fatalf("unexpected rolling mean at %d", i)
+		}
+		if i >= 12 && math.Abs(row.Float(yoy.GetName())-(prices[i]/prices[i-12]-1)) > 1e-9 {
+			t.Fatalf("unexpected pct change at %d", i)
+		}
+	}
+}
+
+func TestWindowInvalidShift(t *testing.T) {
+	d := loadLondon(t)
+	price := d.GetColumnByName("average_price")
+	columns := len(d.Columns())
+	for _, n := range []int{0, -1} {
+		if _, err := d.Lag(price, n, data.Window{}); !errors.Is(err, constant.ErrInvalidParam) {
+			t.Fatalf("lag %d: expect invalid param, got %v", n, err)
+		}
+		if _, err := d.Lead(price, n, data.Window{}); !errors.Is(err, constant.ErrInvalidParam) {
+			t.Fatalf("lead %d: expect invalid param, got %v", n, err)
+		}
+		if _, err := d.PctChange(price, n, data.Window{}); !errors.Is(err, constant.ErrInvalidParam) {
+			t.Fatalf("pct_change %d: expect invalid param, got %v", n, err)
+		}
+		if _, err := d.Rolling(price, n, data.AggMean, data.Window{}); !errors.Is(err, constant.ErrInvalidParam) {
+			t.Fatalf("rolling %d: expect invalid param, got %v", n, err)
+		}
+	}
+	if len(d.Columns()) != columns {
+		t.Fatal("invalid shift should not add columns")
+	}
+}
@@ -0,0 +1,58 @@
+package ml
+
+import (
+	"bytes"
+	"ml/data"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	d := loadLondon(t).Head(2)
+	d.Normalize(d.GetColumnByName("average_price"), data.Max)
+	var buf bytes.Buffer
+	err := d.WriteCSV(&buf, data.WriteCSVOptions{
+		Delimiter: ';',
+		Null:      "NA",
+		Precision: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "date;area;average_price;code;houses_sold;no_of_crimes;borough_flag\n" +
+		"1995-01-01;city of london;1.000;E09000001;17;NA;1\n" +
+		"1995-02-01;city of london;0.899;E09000001;7;NA;1\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected csv:\n%s", buf.String())
+	}
+	buf.Reset()
+	if err := d.WriteCSV(&buf, data.WriteCSVOptions{NoHeader: true, Precision: data.PrecisionShortest}); err != nil {
+		t.Fatal(err)
+	}
+	loaded := data.NewData()
+	for _, col := range londonColumns() {
+		loaded.AddColumn(col)
+	}
+	loaded.AddColumn(data.NewFloatColumn("average_price", 2))
+	if err := loaded.LoadFromCSV(strings.NewReader(buf.String()), false); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Row(1).Float("average_price") != d.Row(1).Float("average_price") {
+		t.Fatal("float not round tripped")
+	}
+}
+
+func TestWriteCSVPrecisionZero(t *testing.T) {
+	d := data.NewData()
+	d.AddColumn(data.NewFloatColumn("x", 0))
+	if err := d.LoadFromCSV(strings.NewReader("1.25\n2.5\n"), false); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := d.WriteCSV(&buf, data.WriteCSVOptions{NoHeader: true}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "1\n2\n" {
+		t.Fatalf("unexpected csv:\n%s", buf.String())
+	}
+}